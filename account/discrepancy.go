@@ -0,0 +1,281 @@
+package account
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/internal/dates"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/products"
+)
+
+const (
+	defaultVolumetricDivisor    = 5000
+	defaultDiscrepancyTolerance = 0.05
+)
+
+// Dimensions are package dimensions in centimetres.
+type Dimensions struct {
+	Length  float64 `json:"length"`
+	Breadth float64 `json:"breadth"`
+	Height  float64 `json:"height"`
+}
+
+// VolumetricWeight returns the volumetric weight in kg for divisor, usually
+// 5000 for surface and air shipments.
+func (d Dimensions) VolumetricWeight(divisor float64) float64 {
+	if divisor <= 0 {
+		divisor = defaultVolumetricDivisor
+	}
+	return d.Length * d.Breadth * d.Height / divisor
+}
+
+func (d Dimensions) IsZero() bool {
+	return d.Length == 0 && d.Breadth == 0 && d.Height == 0
+}
+
+// Discrepancy is one weight discrepancy raised by a courier. Raw keeps the
+// full record as returned by Shiprocket.
+type Discrepancy struct {
+	ID                 int64
+	AWB                string
+	OrderID            string
+	ChannelOrderID     string
+	Courier            string
+	SKU                string
+	Quantity           int
+	Status             string
+	DeclaredWeight     float64
+	DeclaredDimensions Dimensions
+	ChargedWeight      float64
+	ChargedDimensions  Dimensions
+	ExtraCharge        float64
+	Images             []string
+	Deadline           time.Time
+	Raw                map[string]any
+}
+
+// Records decodes the discrepancy rows into typed records.
+func (r *DiscrepancyResponse) Records() []Discrepancy {
+	records := make([]Discrepancy, 0, len(r.Data))
+	for _, raw := range r.Data {
+		record := Discrepancy{
+			ID:                 int64(rawNumber(raw, "id", "discrepancy_id")),
+			AWB:                rawString(raw, "awb_code", "awb"),
+			OrderID:            rawString(raw, "order_id"),
+			ChannelOrderID:     rawString(raw, "channel_order_id"),
+			Courier:            rawString(raw, "courier_name", "courier"),
+			SKU:                rawString(raw, "sku", "product_sku"),
+			Quantity:           int(rawNumber(raw, "quantity", "product_quantity")),
+			Status:             rawString(raw, "status"),
+			DeclaredWeight:     rawNumber(raw, "entered_weight", "applied_weight", "declared_weight"),
+			DeclaredDimensions: rawDimensions(raw, "entered_dimensions", "applied_dimensions", "declared_dimensions"),
+			ChargedWeight:      rawNumber(raw, "charged_weight", "courier_weight"),
+			ChargedDimensions:  rawDimensions(raw, "charged_dimensions", "courier_dimensions"),
+			ExtraCharge:        rawAmount(raw, "discrepancy_charges", "extra_weight_charges", "charged_amount", "difference_amount"),
+			Images:             rawStrings(raw, "courier_images", "images", "product_images"),
+			Deadline:           rawTime(raw, "dispute_deadline", "auto_accept_date", "deadline"),
+			Raw:                raw,
+		}
+		if record.Quantity < 1 {
+			record.Quantity = 1
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+type DisputeDecision string
+
+const (
+	DecisionDispute DisputeDecision = "dispute"
+	DecisionAccept  DisputeDecision = "accept"
+	// DecisionReview marks records the product master cannot settle, such
+	// as unknown SKUs.
+	DecisionReview DisputeDecision = "review"
+)
+
+type AssessOptions struct {
+	// Tolerance is the weight difference, in kg, treated as a match.
+	// Defaults to 0.05.
+	Tolerance float64
+	// VolumetricDivisor defaults to 5000.
+	VolumetricDivisor float64
+	// MinCharge skips disputes worth less than this many rupees.
+	MinCharge float64
+	// Now defaults to time.Now. Records past their deadline are accepted.
+	Now func() time.Time
+}
+
+type DisputeAssessment struct {
+	Discrepancy Discrepancy
+	Product     *products.Summary
+	// ExpectedWeight is the weight the product master supports: the larger
+	// of dead and volumetric weight, times the quantity.
+	ExpectedWeight float64
+	Decision       DisputeDecision
+	Reason         string
+}
+
+// AssessDiscrepancies cross-checks discrepancies with the product master,
+// matched by SKU, and decides which are worth disputing. A discrepancy is
+// disputed when the product master supports a weight at or below what was
+// declared and the courier charged for more.
+func AssessDiscrepancies(records []Discrepancy, catalog []products.Summary, opts AssessOptions) []DisputeAssessment {
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = defaultDiscrepancyTolerance
+	}
+	if opts.VolumetricDivisor <= 0 {
+		opts.VolumetricDivisor = defaultVolumetricDivisor
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+
+	bySKU := make(map[string]*products.Summary, len(catalog))
+	for i := range catalog {
+		bySKU[strings.ToLower(strings.TrimSpace(catalog[i].SKU))] = &catalog[i]
+	}
+
+	assessments := make([]DisputeAssessment, 0, len(records))
+	for _, record := range records {
+		assessment := DisputeAssessment{Discrepancy: record}
+		product := bySKU[strings.ToLower(strings.TrimSpace(record.SKU))]
+		switch {
+		case !record.Deadline.IsZero() && opts.Now().After(record.Deadline):
+			assessment.Decision = DecisionAccept
+			assessment.Reason = "dispute deadline has passed"
+		case product == nil:
+			assessment.Decision = DecisionReview
+			assessment.Reason = "SKU not found in product master"
+		default:
+			assessment.Product = product
+			assessment.ExpectedWeight = productWeight(product, opts.VolumetricDivisor) * float64(record.Quantity)
+			assessment.Decision, assessment.Reason = decide(record, assessment.ExpectedWeight, opts)
+		}
+		assessments = append(assessments, assessment)
+	}
+	return assessments
+}
+
+func decide(record Discrepancy, expected float64, opts AssessOptions) (DisputeDecision, string) {
+	switch {
+	case expected <= 0:
+		return DecisionReview, "product master has no weight or dimensions"
+	case record.DeclaredWeight > 0 && record.DeclaredWeight+opts.Tolerance < expected:
+		return DecisionAccept, fmt.Sprintf("declared %.3g kg is below the product master weight %.3g kg", record.DeclaredWeight, expected)
+	case record.ChargedWeight <= expected+opts.Tolerance:
+		return DecisionAccept, fmt.Sprintf("charged %.3g kg is within the product master weight %.3g kg", record.ChargedWeight, expected)
+	case record.ExtraCharge > 0 && record.ExtraCharge < opts.MinCharge:
+		return DecisionAccept, fmt.Sprintf("extra charge %.2f is below the dispute minimum", record.ExtraCharge)
+	default:
+		return DecisionDispute, fmt.Sprintf("charged %.3g kg exceeds the product master weight %.3g kg", record.ChargedWeight, expected)
+	}
+}
+
+func productWeight(product *products.Summary, divisor float64) float64 {
+	weight, _ := strconv.ParseFloat(strings.TrimSpace(product.Weight.String()), 64)
+	if dimensions, ok := parseDimensions(product.Dimensions); ok {
+		weight = max(weight, dimensions.VolumetricWeight(divisor))
+	}
+	return weight
+}
+
+func rawValue(raw map[string]any, keys ...string) any {
+	for _, key := range keys {
+		if value, ok := raw[key]; ok && value != nil && value != "" {
+			return value
+		}
+	}
+	return nil
+}
+
+func rawString(raw map[string]any, keys ...string) string {
+	switch value := rawValue(raw, keys...).(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	default:
+		return fmt.Sprint(value)
+	}
+}
+
+func rawNumber(raw map[string]any, keys ...string) float64 {
+	switch value := rawValue(raw, keys...).(type) {
+	case float64:
+		return value
+	case string:
+		value = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "kg")
+		number, _ := strconv.ParseFloat(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 64)
+		return number
+	default:
+		return 0
+	}
+}
+
+// rawAmount reads a charge in rupees, which may carry a currency prefix such
+// as "₹ 80".
+func rawAmount(raw map[string]any, keys ...string) float64 {
+	switch value := rawValue(raw, keys...).(type) {
+	case float64:
+		return value
+	case string:
+		amount, _ := ParseMoney(value)
+		return amount.Rupees()
+	default:
+		return 0
+	}
+}
+
+func rawStrings(raw map[string]any, keys ...string) []string {
+	switch value := rawValue(raw, keys...).(type) {
+	case []any:
+		values := make([]string, 0, len(value))
+		for _, item := range value {
+			if text, ok := item.(string); ok && text != "" {
+				values = append(values, text)
+			}
+		}
+		return values
+	case string:
+		var values []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				values = append(values, item)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+func rawDimensions(raw map[string]any, keys ...string) Dimensions {
+	switch value := rawValue(raw, keys...).(type) {
+	case map[string]any:
+		return Dimensions{
+			Length:  rawNumber(value, "length", "l"),
+			Breadth: rawNumber(value, "breadth", "width", "b"),
+			Height:  rawNumber(value, "height", "h"),
+		}
+	case string:
+		dimensions, _ := parseDimensions(value)
+		return dimensions
+	default:
+		return Dimensions{}
+	}
+}
+
+func parseDimensions(value string) (Dimensions, bool) {
+	length, breadth, height, ok := products.ParseDimensions(value)
+	return Dimensions{Length: length, Breadth: breadth, Height: height}, ok
+}
+
+func rawTime(raw map[string]any, keys ...string) time.Time {
+	parsed, _ := dates.Parse(rawString(raw, keys...))
+	return parsed
+}
@@ -0,0 +1,89 @@
+package account
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in Indian rupees, stored in paise so that totals add up
+// exactly. It decodes from JSON numbers and from strings such as "1,234.50",
+// "₹ 80" or "-Rs 12". Strings that are not amounts, such as "N/A", fail to
+// decode.
+type Money int64
+
+func Rupees(amount float64) Money {
+	return Money(math.Round(amount * 100))
+}
+
+func (m Money) Paise() int64 {
+	return int64(m)
+}
+
+func (m Money) Rupees() float64 {
+	return float64(m) / 100
+}
+
+// String formats the amount with two decimals, for example "-1234.50".
+func (m Money) String() string {
+	sign := ""
+	paise := int64(m)
+	if paise < 0 {
+		sign = "-"
+		paise = -paise
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, paise/100, paise%100)
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = 0
+		return nil
+	}
+	var text string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &text); err != nil {
+			return err
+		}
+	} else {
+		text = string(data)
+	}
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
+
+// ParseMoney parses a rupee amount. The currency prefix is matched without
+// regard to case and may follow the sign. Empty values parse as zero.
+func ParseMoney(value string) (Money, error) {
+	text := strings.TrimSpace(value)
+	sign := ""
+	if strings.HasPrefix(text, "-") || strings.HasPrefix(text, "+") {
+		sign, text = text[:1], strings.TrimSpace(text[1:])
+	}
+	for _, prefix := range []string{"₹", "Rs.", "Rs", "INR"} {
+		if len(text) >= len(prefix) && strings.EqualFold(text[:len(prefix)], prefix) {
+			text = strings.TrimSpace(text[len(prefix):])
+		}
+	}
+	text = strings.ReplaceAll(text, ",", "")
+	if text == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(sign+text, 64)
+	if err != nil {
+		return 0, fmt.Errorf("parse money %q: %w", value, err)
+	}
+	return Rupees(amount), nil
+}
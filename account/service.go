@@ -15,52 +15,56 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) GetWalletBalance(ctx context.Context) (*WalletBalanceResponse, error) {
+func (s *Service) GetWalletBalance(ctx context.Context, opts ...internalclient.CallOption) (*WalletBalanceResponse, error) {
 	var response WalletBalanceResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/account/details/wallet-balance",
-	}, &response); err != nil {
+		Operation: "account.GetWalletBalance",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/account/details/wallet-balance",
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) GetStatement(ctx context.Context, params *StatementParams) (*StatementResponse, error) {
+func (s *Service) GetStatement(ctx context.Context, params *StatementParams, opts ...internalclient.CallOption) (*StatementResponse, error) {
 	var response StatementResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/account/details/statement",
+		Operation: "account.GetStatement",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/account/details/statement",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) GetDiscrepancy(ctx context.Context) (*DiscrepancyResponse, error) {
+func (s *Service) GetDiscrepancy(ctx context.Context, opts ...internalclient.CallOption) (*DiscrepancyResponse, error) {
 	var response DiscrepancyResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/billing/discrepancy",
-	}, &response); err != nil {
+		Operation: "account.GetDiscrepancy",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/billing/discrepancy",
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CheckImport(ctx context.Context, request *ImportCheckRequest) (*ImportCheckResponse, error) {
+func (s *Service) CheckImport(ctx context.Context, request *ImportCheckRequest, opts ...internalclient.CallOption) (*ImportCheckResponse, error) {
 	var response ImportCheckResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/errors/{import_id}/check",
+		Operation: "account.CheckImport",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/errors/{import_id}/check",
 		PathParams: map[string]string{
 			"import_id": request.ImportID,
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -2,11 +2,16 @@ package account
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/products"
 )
 
 func TestAccountEndpoints(t *testing.T) {
@@ -47,3 +52,143 @@ func TestAccountEndpoints(t *testing.T) {
 		t.Fatalf("unexpected import response: %+v err=%v", importResp, err)
 	}
 }
+
+func TestWalletGuard(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"balance_amount":"₹ 1,500.00"}}`))
+	}))
+	defer server.Close()
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+
+	selected := []courier.ServiceableCourier{
+		{CourierName: "Bluedart", Rate: 400},
+		{CourierName: "Delhivery", FreightCharge: 350, CODCharges: 50},
+		{CourierName: "Xpressbees", Rate: 300},
+	}
+	if got := EstimateCharges(selected); got != 1100 {
+		t.Fatalf("expected estimate 1100, got %v", got)
+	}
+
+	var alerts []WalletAlert
+	guard := s.WalletGuard(WalletGuardConfig{
+		Thresholds:  []float64{200, 1000},
+		OnThreshold: func(_ context.Context, alert WalletAlert) { alerts = append(alerts, alert) },
+	})
+	check, err := guard.CheckCouriers(context.Background(), selected)
+	if err != nil {
+		t.Fatalf("CheckCouriers returned error: %v", err)
+	}
+	if check.Balance != 1500 || check.Remaining != 400 || check.Shipments != 3 || !check.Sufficient() {
+		t.Fatalf("unexpected check %+v", check)
+	}
+	if len(alerts) != 1 || alerts[0].Threshold != 1000 {
+		t.Fatalf("expected the 1000 threshold to fire, got %+v", alerts)
+	}
+	if _, err := guard.CheckCouriers(context.Background(), selected); err != nil || len(alerts) != 1 {
+		t.Fatalf("expected a threshold to fire once, got %+v err=%v", alerts, err)
+	}
+
+	_, err = guard.Check(context.Background(), 1600)
+	var insufficient *InsufficientBalanceError
+	if !errors.As(err, &insufficient) || insufficient.Check.Remaining != -100 {
+		t.Fatalf("expected InsufficientBalanceError, got %v", err)
+	}
+	if len(alerts) != 2 || alerts[1].Threshold != 200 {
+		t.Fatalf("expected the 200 threshold to fire, got %+v", alerts)
+	}
+
+	var shortfall *WalletCheck
+	warn := s.WalletGuard(WalletGuardConfig{
+		Mode:        WalletGuardWarn,
+		Margin:      1.5,
+		OnShortfall: func(_ context.Context, check WalletCheck) { shortfall = &check },
+	})
+	check, err = warn.Check(context.Background(), 1100)
+	if err != nil || check.Estimated != 1650 || check.Sufficient() {
+		t.Fatalf("expected a warning check, got %+v err=%v", check, err)
+	}
+	if shortfall == nil || shortfall.Remaining != -150 {
+		t.Fatalf("expected OnShortfall to be called, got %+v", shortfall)
+	}
+}
+
+func TestDiscrepancyRecordsAndAssessment(t *testing.T) {
+	response := DiscrepancyResponse{Data: []map[string]any{
+		{"id": float64(11), "awb_code": "AWB1", "sku": "MUG-1", "entered_weight": "0.5", "charged_weight": 1.5, "charged_dimensions": "20x20x20", "discrepancy_charges": "45.00", "courier_images": []any{"https://img/1.jpg"}, "dispute_deadline": "2026-10-25"},
+		{"id": float64(12), "awb_code": "AWB2", "sku": "LAMP-1", "entered_weight": "0.5", "charged_weight": "2 kg", "quantity": "1", "discrepancy_charges": "₹ 80"},
+		{"id": float64(13), "awb_code": "AWB3", "sku": "MUG-1", "entered_weight": "0.5", "charged_weight": 0.42, "quantity": float64(1)},
+		{"id": float64(14), "awb_code": "AWB4", "sku": "UNKNOWN", "charged_weight": 3},
+		{"id": float64(15), "awb_code": "AWB5", "sku": "MUG-1", "charged_weight": 3, "dispute_deadline": "2026-10-01"},
+	}}
+	records := response.Records()
+	if len(records) != 5 {
+		t.Fatalf("expected 5 records, got %d", len(records))
+	}
+	first := records[0]
+	if first.ID != 11 || first.DeclaredWeight != 0.5 || first.ChargedWeight != 1.5 || first.ExtraCharge != 45 || first.ChargedDimensions != (Dimensions{Length: 20, Breadth: 20, Height: 20}) || len(first.Images) != 1 || first.Deadline.Format("2006-01-02") != "2026-10-25" || first.Quantity != 1 {
+		t.Fatalf("unexpected record %+v", first)
+	}
+
+	if records[1].ChargedWeight != 2 || records[1].ExtraCharge != 80 {
+		t.Fatalf("unexpected record %+v", records[1])
+	}
+
+	catalog := []products.Summary{
+		{SKU: "mug-1", Weight: "0.4", Dimensions: "10x10x10"},
+		{SKU: "LAMP-1", Weight: "1.2", Dimensions: "30x20x20"},
+	}
+	now := func() time.Time { return time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC) }
+	assessments := AssessDiscrepancies(records, catalog, AssessOptions{Now: now})
+
+	want := []DisputeDecision{DecisionDispute, DecisionAccept, DecisionAccept, DecisionReview, DecisionAccept}
+	for i, decision := range want {
+		if assessments[i].Decision != decision {
+			t.Fatalf("record %d: expected %s, got %s (%s)", i, decision, assessments[i].Decision, assessments[i].Reason)
+		}
+	}
+	if assessments[0].ExpectedWeight != 0.4 || assessments[0].Product == nil {
+		t.Fatalf("unexpected assessment %+v", assessments[0])
+	}
+	if assessments[1].ExpectedWeight != 2.4 {
+		t.Fatalf("expected volumetric weight 2.4, got %v", assessments[1].ExpectedWeight)
+	}
+}
+
+func TestMoney(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Money
+		text  string
+	}{
+		{`"1,234.50"`, 123450, "1234.50"},
+		{`"₹ 80"`, 8000, "80.00"},
+		{`"Rs. 12.345"`, 1235, "12.35"},
+		{`"-₹80"`, -8000, "-80.00"},
+		{`"rs 80"`, 8000, "80.00"},
+		{`"INR -5"`, -500, "-5.00"},
+		{`"-"`, 0, "0.00"},
+		{`-0.1`, -10, "-0.10"},
+		{`""`, 0, "0.00"},
+		{`null`, 0, "0.00"},
+	}
+	for _, tt := range tests {
+		var got Money
+		if err := json.Unmarshal([]byte(tt.input), &got); err != nil {
+			t.Fatalf("unmarshal %s: %v", tt.input, err)
+		}
+		if got != tt.want || got.String() != tt.text {
+			t.Fatalf("unmarshal %s: got %d (%s), want %d (%s)", tt.input, got, got, tt.want, tt.text)
+		}
+	}
+	encoded, err := json.Marshal(struct{ Amount Money }{Rupees(99.9)})
+	if err != nil || string(encoded) != `{"Amount":99.90}` {
+		t.Fatalf("unexpected encoding %s err=%v", encoded, err)
+	}
+	if _, err := ParseMoney("twelve"); err == nil {
+		t.Fatal("expected parse error")
+	}
+	var invalid Money
+	if err := json.Unmarshal([]byte(`"N/A"`), &invalid); err == nil {
+		t.Fatal("expected an error decoding N/A")
+	}
+}
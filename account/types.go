@@ -79,7 +79,8 @@ type ImportCheckRequest struct {
 
 type ImportCheckResponse struct {
 	Data struct {
-		Status  string `json:"status"`
-		Message string `json:"message"`
+		Status       string `json:"status"`
+		Message      string `json:"message"`
+		ErrorFileURL string `json:"error_file_url,omitempty"`
 	} `json:"data"`
 }
@@ -0,0 +1,168 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
+)
+
+type WalletGuardMode int
+
+const (
+	// WalletGuardBlock makes Check fail with InsufficientBalanceError.
+	WalletGuardBlock WalletGuardMode = iota
+	// WalletGuardWarn reports a shortfall through OnShortfall but lets the
+	// batch proceed.
+	WalletGuardWarn
+)
+
+type WalletGuardConfig struct {
+	Mode WalletGuardMode
+	// Margin scales estimates to leave room for weight discrepancies and
+	// other charges applied after booking. Defaults to 1.
+	Margin float64
+	// Thresholds are balances, in rupees, that trigger OnThreshold when a
+	// batch would take the wallet below them.
+	Thresholds  []float64
+	OnThreshold func(ctx context.Context, alert WalletAlert)
+	// OnShortfall is called in WalletGuardWarn mode when the balance does
+	// not cover a batch.
+	OnShortfall func(ctx context.Context, check WalletCheck)
+}
+
+// WalletCheck is the result of comparing a batch estimate with the wallet.
+type WalletCheck struct {
+	Balance   float64
+	Estimated float64
+	Remaining float64
+	Shipments int
+}
+
+func (c WalletCheck) Sufficient() bool {
+	return c.Remaining >= 0
+}
+
+type WalletAlert struct {
+	Threshold float64
+	Balance   float64
+	Remaining float64
+}
+
+type InsufficientBalanceError struct {
+	Check WalletCheck
+}
+
+func (e *InsufficientBalanceError) Error() string {
+	return fmt.Sprintf("shiprocket wallet balance %.2f does not cover estimated charges %.2f", e.Check.Balance, e.Check.Estimated)
+}
+
+// WalletGuard checks the prepaid wallet before a batch of AWB assignments,
+// so a batch is not abandoned halfway when the wallet runs dry.
+type WalletGuard struct {
+	service *Service
+	config  WalletGuardConfig
+
+	mu    sync.Mutex
+	fired map[float64]bool
+}
+
+func (s *Service) WalletGuard(cfg WalletGuardConfig) *WalletGuard {
+	if cfg.Margin <= 0 {
+		cfg.Margin = 1
+	}
+	cfg.Thresholds = append([]float64(nil), cfg.Thresholds...)
+	sort.Sort(sort.Reverse(sort.Float64Slice(cfg.Thresholds)))
+	return &WalletGuard{service: s, config: cfg, fired: map[float64]bool{}}
+}
+
+// CheckCouriers estimates a batch from the courier chosen for each pending
+// shipment and checks it against the wallet balance.
+func (g *WalletGuard) CheckCouriers(ctx context.Context, selected []courier.ServiceableCourier) (*WalletCheck, error) {
+	return g.check(ctx, EstimateCharges(selected), len(selected))
+}
+
+// Check compares an estimated batch cost with the wallet balance. It fails
+// with InsufficientBalanceError in WalletGuardBlock mode when the balance
+// does not cover the estimate.
+func (g *WalletGuard) Check(ctx context.Context, estimated float64) (*WalletCheck, error) {
+	return g.check(ctx, estimated, 0)
+}
+
+func (g *WalletGuard) check(ctx context.Context, estimated float64, shipments int) (*WalletCheck, error) {
+	wallet, err := g.service.GetWalletBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	amount, err := ParseMoney(wallet.Data.BalanceAmount.String())
+	if err != nil {
+		return nil, fmt.Errorf("parse wallet balance: %w", err)
+	}
+	balance := amount.Rupees()
+
+	estimated *= g.config.Margin
+	check := WalletCheck{
+		Balance:   balance,
+		Estimated: estimated,
+		Remaining: balance - estimated,
+		Shipments: shipments,
+	}
+
+	for _, alert := range g.crossed(check) {
+		g.config.OnThreshold(ctx, alert)
+	}
+
+	if check.Sufficient() {
+		return &check, nil
+	}
+	if g.config.Mode == WalletGuardWarn {
+		if g.config.OnShortfall != nil {
+			g.config.OnShortfall(ctx, check)
+		}
+		return &check, nil
+	}
+	return &check, &InsufficientBalanceError{Check: check}
+}
+
+// crossed returns the thresholds the batch would cross. Each threshold fires
+// once and is re-armed when the balance is back above it.
+func (g *WalletGuard) crossed(check WalletCheck) []WalletAlert {
+	if g.config.OnThreshold == nil {
+		return nil
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var alerts []WalletAlert
+	for _, threshold := range g.config.Thresholds {
+		if check.Remaining >= threshold {
+			g.fired[threshold] = false
+			continue
+		}
+		if g.fired[threshold] {
+			continue
+		}
+		g.fired[threshold] = true
+		alerts = append(alerts, WalletAlert{Threshold: threshold, Balance: check.Balance, Remaining: check.Remaining})
+	}
+	return alerts
+}
+
+// EstimateCharges sums the quoted rate of each selected courier, falling
+// back to the freight charge when no rate is quoted.
+func EstimateCharges(selected []courier.ServiceableCourier) float64 {
+	var total float64
+	for _, c := range selected {
+		switch {
+		case c.Rate > 0:
+			total += c.Rate.Float64()
+		case c.FreightCharge > 0:
+			total += c.FreightCharge.Float64() + c.CODCharges.Float64()
+		default:
+			total += c.Rates.Float64()
+		}
+	}
+	return total
+}
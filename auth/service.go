@@ -54,25 +54,26 @@ func NewService(client *internalclient.Client, credentials *Credentials) *Servic
 	}
 }
 
-func (s *Service) Login(ctx context.Context) (*LoginResponse, error) {
+func (s *Service) Login(ctx context.Context, opts ...internalclient.CallOption) (*LoginResponse, error) {
 	if s.credentials == nil {
 		return nil, ErrCredentialsRequired
 	}
 
-	return s.LoginWithRequest(ctx, s.credentials)
+	return s.LoginWithRequest(ctx, s.credentials, opts...)
 }
 
-func (s *Service) LoginWithRequest(ctx context.Context, request *LoginRequest) (*LoginResponse, error) {
+func (s *Service) LoginWithRequest(ctx context.Context, request *LoginRequest, opts ...internalclient.CallOption) (*LoginResponse, error) {
 	if request == nil {
 		return nil, ErrCredentialsRequired
 	}
 
 	var response LoginResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/auth/login",
-		JSONBody: request,
-	}, &response)
+		Operation: "auth.LoginWithRequest",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/auth/login",
+		JSONBody:  request,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -80,15 +81,16 @@ func (s *Service) LoginWithRequest(ctx context.Context, request *LoginRequest) (
 	return &response, nil
 }
 
-func (s *Service) LoginWithCredentials(ctx context.Context, credentials Credentials) (*LoginResponse, error) {
-	return s.LoginWithRequest(ctx, &credentials)
+func (s *Service) LoginWithCredentials(ctx context.Context, credentials Credentials, opts ...internalclient.CallOption) (*LoginResponse, error) {
+	return s.LoginWithRequest(ctx, &credentials, opts...)
 }
 
-func (s *Service) Logout(ctx context.Context) error {
+func (s *Service) Logout(ctx context.Context, opts ...internalclient.CallOption) error {
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/auth/logout",
-	}, nil); err != nil {
+		Operation: "auth.Logout",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/auth/logout",
+	}, nil, opts...); err != nil {
 		return err
 	}
 
@@ -99,20 +101,16 @@ func (s *Service) Logout(ctx context.Context) error {
 	return nil
 }
 
-func (s *Service) LogoutToken(ctx context.Context, token string) error {
-	client := internalclient.New(
-		s.client.BaseURL,
-		internalclient.WithHTTPClient(s.client.HTTPClient),
-		internalclient.WithToken(token),
-		internalclient.WithUserAgent(s.client.UserAgent),
-		internalclient.WithLogger(s.client.Logger),
-		internalclient.WithHooks(s.client.Hooks...),
-	)
+func (s *Service) LogoutToken(ctx context.Context, token string, opts ...internalclient.CallOption) error {
+	client := s.client.Clone()
+	client.Token = token
+	client.TokenSource = nil
 
 	if err := client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/auth/logout",
-	}, nil); err != nil {
+		Operation: "auth.LogoutToken",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/auth/logout",
+	}, nil, opts...); err != nil {
 		return err
 	}
 
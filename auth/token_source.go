@@ -18,14 +18,9 @@ type credentialTokenSource struct {
 }
 
 func NewCredentialsTokenSource(client *internalclient.Client, credentials Credentials) internalclient.TokenSource {
-	loginClient := internalclient.New(
-		client.BaseURL,
-		internalclient.WithHTTPClient(client.HTTPClient),
-		internalclient.WithUserAgent(client.UserAgent),
-		internalclient.WithLogger(client.Logger),
-		internalclient.WithHooks(client.Hooks...),
-		internalclient.WithMiddleware(client.Middleware...),
-	)
+	loginClient := client.Clone()
+	loginClient.Token = ""
+	loginClient.TokenSource = nil
 
 	return &credentialTokenSource{
 		client:      loginClient,
@@ -0,0 +1,416 @@
+package billing
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/account"
+)
+
+const (
+	defaultAmountTolerance = account.Money(100)
+	defaultWeightTolerance = 0.01
+)
+
+// Reconciler matches wallet statement entries with your shipment ledger.
+// The zero value is ready to use.
+type Reconciler struct {
+	// AmountTolerance is the difference ignored when comparing charges.
+	// Defaults to one rupee.
+	AmountTolerance account.Money
+	// WeightTolerance is the difference, in kg, ignored when comparing
+	// charged weights. Defaults to 0.01.
+	WeightTolerance float64
+}
+
+// Reconcile reconciles entries against expected using the default
+// tolerances.
+func Reconcile(entries []account.StatementEntry, expected []Shipment) (*Report, error) {
+	return Reconciler{}.Reconcile(entries, expected)
+}
+
+type chargeKind int
+
+const (
+	chargeOther chargeKind = iota
+	chargeFreight
+	chargeCOD
+	chargeRTO
+)
+
+type debit struct {
+	amount        account.Money
+	transactionID string
+}
+
+// entryKey identifies a statement entry for deduplication. Transaction IDs
+// are not documented as unique per debit, so the ID alone is not enough.
+type entryKey struct {
+	transactionID string
+	awb           string
+	returnAWB     string
+	debit         string
+	credit        string
+	createdAt     string
+}
+
+func keyOf(entry account.StatementEntry) entryKey {
+	key := entryKey{
+		transactionID: entry.TransactionID,
+		awb:           strings.TrimSpace(entry.AWBCode),
+		debit:         strings.TrimSpace(entry.DebitAmount),
+		credit:        strings.TrimSpace(entry.CreditAmount),
+		createdAt:     strings.TrimSpace(entry.CreatedAt),
+	}
+	if entry.ReturnAWBCode != nil {
+		key.returnAWB = strings.TrimSpace(*entry.ReturnAWBCode)
+	}
+	return key
+}
+
+type awbEntries struct {
+	charges ShipmentCharges
+	debits  map[chargeKind][]debit
+	credits map[chargeKind]account.Money
+}
+
+// Reconcile parses the statement amounts with account.ParseMoney and the
+// weights in kg, totals them per AWB
+// and reports charges that do not match the ledger. Entries repeated in
+// full, for example from overlapping statement pages, are counted once.
+// Entries that share a transaction ID but differ in amount, AWB or time
+// are kept, so a reused ID cannot hide a duplicate debit. Entries without
+// an AWB, such as recharges, only count
+// towards the report totals.
+func (r Reconciler) Reconcile(entries []account.StatementEntry, expected []Shipment) (*Report, error) {
+	if r.AmountTolerance <= 0 {
+		r.AmountTolerance = defaultAmountTolerance
+	}
+	if r.WeightTolerance <= 0 {
+		r.WeightTolerance = defaultWeightTolerance
+	}
+
+	ledger := make(map[string]Shipment, len(expected))
+	for _, shipment := range expected {
+		ledger[strings.TrimSpace(shipment.AWB)] = shipment
+	}
+
+	report := &Report{}
+	byAWB := map[string]*awbEntries{}
+	var order []string
+	seen := map[entryKey]bool{}
+
+	for i, entry := range entries {
+		if entry.TransactionID != "" {
+			key := keyOf(entry)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+		}
+
+		debitAmount, err := account.ParseMoney(entry.DebitAmount)
+		if err != nil {
+			return nil, fmt.Errorf("statement entry %d: parse debit_amount %q: %w", i, entry.DebitAmount, err)
+		}
+		creditAmount, err := account.ParseMoney(entry.CreditAmount)
+		if err != nil {
+			return nil, fmt.Errorf("statement entry %d: parse credit_amount %q: %w", i, entry.CreditAmount, err)
+		}
+		weight, err := parseWeight(entry.ChargedWeight)
+		if err != nil {
+			return nil, fmt.Errorf("statement entry %d: parse charged_weight %q: %w", i, entry.ChargedWeight, err)
+		}
+		report.TotalDebits += debitAmount
+		report.TotalCredits += creditAmount
+
+		awb := strings.TrimSpace(entry.AWBCode)
+		if awb == "" && entry.ReturnAWBCode != nil {
+			awb = strings.TrimSpace(*entry.ReturnAWBCode)
+		}
+		if awb == "" {
+			continue
+		}
+
+		state, ok := byAWB[awb]
+		if !ok {
+			state = &awbEntries{
+				charges: ShipmentCharges{AWB: awb, OrderID: entry.OrderID},
+				debits:  map[chargeKind][]debit{},
+				credits: map[chargeKind]account.Money{},
+			}
+			byAWB[awb] = state
+			order = append(order, awb)
+		}
+
+		kind := classify(entry)
+		if debitAmount > 0 {
+			switch kind {
+			case chargeFreight:
+				state.charges.Freight += debitAmount
+			case chargeCOD:
+				state.charges.COD += debitAmount
+			case chargeRTO:
+				state.charges.RTO += debitAmount
+			default:
+				state.charges.Other += debitAmount
+			}
+			state.debits[kind] = append(state.debits[kind], debit{amount: debitAmount, transactionID: entry.TransactionID})
+		}
+		if creditAmount > 0 {
+			state.charges.Credits += creditAmount
+			state.credits[kind] += creditAmount
+		}
+		state.charges.ChargedWeight = math.Max(state.charges.ChargedWeight, weight)
+	}
+
+	for _, awb := range order {
+		state := byAWB[awb]
+		if shipment, ok := ledger[awb]; ok && shipment.OrderID != "" {
+			state.charges.OrderID = shipment.OrderID
+		}
+		report.Shipments = append(report.Shipments, state.charges)
+		report.Findings = append(report.Findings, r.check(state, ledger)...)
+	}
+	return report, nil
+}
+
+func (r Reconciler) check(state *awbEntries, ledger map[string]Shipment) []Finding {
+	charges := state.charges
+	shipment, known := ledger[charges.AWB]
+	if !known {
+		if charges.Net() <= r.AmountTolerance {
+			return nil
+		}
+		return []Finding{{
+			Kind:           FindingUnknownAWB,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeFreight, chargeCOD, chargeRTO, chargeOther),
+			Actual:         charges.Net(),
+			Amount:         charges.Net(),
+			Detail:         "debited AWB is not in the ledger",
+		}}
+	}
+
+	var findings []Finding
+	net := map[chargeKind]account.Money{}
+	for _, kind := range []chargeKind{chargeFreight, chargeCOD, chargeRTO} {
+		finding, duplicated := r.duplicates(state, kind)
+		if finding != nil {
+			finding.OrderID = charges.OrderID
+			findings = append(findings, *finding)
+		}
+		var total account.Money
+		for _, d := range state.debits[kind] {
+			total += d.amount
+		}
+		net[kind] = total - duplicated - state.credits[kind]
+	}
+
+	if freight := net[chargeFreight]; freight > shipment.Freight+r.AmountTolerance {
+		detail := "freight above ledger"
+		if shipment.Weight > 0 && charges.ChargedWeight > shipment.Weight+r.WeightTolerance {
+			detail = fmt.Sprintf("freight above ledger; charged weight %.3g kg vs %.3g kg", charges.ChargedWeight, shipment.Weight)
+		}
+		findings = append(findings, Finding{
+			Kind:           FindingOvercharge,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeFreight),
+			Expected:       shipment.Freight,
+			Actual:         freight,
+			Amount:         freight - shipment.Freight,
+			Detail:         detail,
+		})
+	} else if shipment.Weight > 0 && charges.ChargedWeight > shipment.Weight+r.WeightTolerance {
+		findings = append(findings, Finding{
+			Kind:           FindingOvercharge,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeFreight),
+			Detail:         fmt.Sprintf("charged weight %.3g kg above ledger %.3g kg; check for a pending weight discrepancy", charges.ChargedWeight, shipment.Weight),
+		})
+	}
+
+	cod := net[chargeCOD]
+	switch {
+	case shipment.RTO && cod > r.AmountTolerance:
+		findings = append(findings, Finding{
+			Kind:           FindingUnreturnedCOD,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeCOD),
+			Actual:         cod,
+			Amount:         cod,
+			Detail:         "COD charge not reversed after RTO",
+		})
+	case !shipment.RTO && cod > shipment.COD+r.AmountTolerance:
+		findings = append(findings, Finding{
+			Kind:           FindingOvercharge,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeCOD),
+			Expected:       shipment.COD,
+			Actual:         cod,
+			Amount:         cod - shipment.COD,
+			Detail:         "COD charge above ledger",
+		})
+	}
+
+	rto := net[chargeRTO]
+	switch {
+	case rto <= r.AmountTolerance:
+	case !shipment.RTO:
+		findings = append(findings, Finding{
+			Kind:           FindingRTOCharge,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeRTO),
+			Actual:         rto,
+			Amount:         rto,
+			Detail:         "RTO charged for a shipment the ledger does not show as returned",
+		})
+	case shipment.RTOCharge > 0 && rto > shipment.RTOCharge+r.AmountTolerance:
+		findings = append(findings, Finding{
+			Kind:           FindingRTOCharge,
+			AWB:            charges.AWB,
+			OrderID:        charges.OrderID,
+			TransactionIDs: state.transactionIDs(chargeRTO),
+			Expected:       shipment.RTOCharge,
+			Actual:         rto,
+			Amount:         rto - shipment.RTOCharge,
+			Detail:         "RTO charge above ledger",
+		})
+	}
+
+	return findings
+}
+
+// duplicates reports debits of the same kind and amount that were not
+// offset by credits, and returns the duplicated amount net of credits.
+func (r Reconciler) duplicates(state *awbEntries, kind chargeKind) (*Finding, account.Money) {
+	counts := map[account.Money][]debit{}
+	var amounts []account.Money
+	for _, d := range state.debits[kind] {
+		if _, ok := counts[d.amount]; !ok {
+			amounts = append(amounts, d.amount)
+		}
+		counts[d.amount] = append(counts[d.amount], d)
+	}
+
+	var duplicated account.Money
+	var transactionIDs []string
+	for _, amount := range amounts {
+		debits := counts[amount]
+		if len(debits) < 2 {
+			continue
+		}
+		duplicated += account.Money(len(debits)-1) * amount
+		for _, d := range debits {
+			transactionIDs = append(transactionIDs, d.transactionID)
+		}
+	}
+	duplicated -= state.credits[kind]
+	if duplicated <= r.AmountTolerance {
+		return nil, 0
+	}
+	return &Finding{
+		Kind:           FindingDuplicateDebit,
+		AWB:            state.charges.AWB,
+		TransactionIDs: transactionIDs,
+		Actual:         duplicated,
+		Amount:         duplicated,
+		Detail:         kind.String() + " debited more than once",
+	}, duplicated
+}
+
+func (s *awbEntries) transactionIDs(kinds ...chargeKind) []string {
+	var ids []string
+	for _, kind := range kinds {
+		for _, d := range s.debits[kind] {
+			if d.transactionID != "" {
+				ids = append(ids, d.transactionID)
+			}
+		}
+	}
+	return ids
+}
+
+func (k chargeKind) String() string {
+	switch k {
+	case chargeFreight:
+		return "freight"
+	case chargeCOD:
+		return "COD"
+	case chargeRTO:
+		return "RTO"
+	default:
+		return "charge"
+	}
+}
+
+// classify sorts an entry by the words in its description, action and
+// charge columns.
+func classify(entry account.StatementEntry) chargeKind {
+	words := strings.FieldsFunc(strings.ToLower(entry.Description+" "+entry.Action+" "+entry.Charge), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	kind := chargeOther
+	for _, word := range words {
+		switch word {
+		case "rto":
+			return chargeRTO
+		case "cod":
+			kind = chargeCOD
+		case "freight", "shipping", "weight", "excess", "forward":
+			if kind == chargeOther {
+				kind = chargeFreight
+			}
+		}
+	}
+	return kind
+}
+
+// parseWeight reads statement weights such as "0.5" or "1.5 kg". Empty
+// values parse as zero.
+func parseWeight(value string) (float64, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	value = strings.TrimSuffix(strings.TrimSuffix(value, "kgs"), "kg")
+	value = strings.ReplaceAll(strings.ReplaceAll(value, ",", ""), " ", "")
+	if value == "" || value == "-" {
+		return 0, nil
+	}
+	return strconv.ParseFloat(value, 64)
+}
+
+var csvHeader = []string{"kind", "awb", "order_id", "transaction_ids", "expected", "actual", "amount", "detail"}
+
+// WriteCSV writes one row per finding.
+func (r *Report) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+	for _, finding := range r.Findings {
+		if err := writer.Write([]string{
+			string(finding.Kind),
+			finding.AWB,
+			finding.OrderID,
+			strings.Join(finding.TransactionIDs, ";"),
+			finding.Expected.String(),
+			finding.Actual.String(),
+			finding.Amount.String(),
+			finding.Detail,
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,132 @@
+package billing
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/account"
+)
+
+func TestReconcileFlagsDiscrepancies(t *testing.T) {
+	entries := []account.StatementEntry{
+		{TransactionID: "t0", Description: "Wallet Recharge", CreditAmount: "5,000.00"},
+		// AWB1 matches the ledger.
+		{TransactionID: "t1", AWBCode: "AWB1", Description: "Freight Charges Applied", DebitAmount: "80.00", ChargedWeight: "0.5"},
+		// AWB2 is charged twice, and for more weight than declared.
+		{TransactionID: "t2", AWBCode: "AWB2", Description: "Freight Charges Applied", DebitAmount: "120.00", ChargedWeight: "1.5 kg"},
+		{TransactionID: "t3", AWBCode: "AWB2", Description: "Freight Charges Applied", DebitAmount: "120.00"},
+		{TransactionID: "t3", AWBCode: "AWB2", Description: "Freight Charges Applied", DebitAmount: "120.00"},
+		{TransactionID: "t4", AWBCode: "AWB2", Description: "Excess Weight Charges", DebitAmount: "₹ 40"},
+		// AWB3 was returned: COD is not reversed and RTO matches the ledger.
+		{TransactionID: "t5", AWBCode: "AWB3", Description: "Freight Charges Applied", DebitAmount: "90"},
+		{TransactionID: "t6", AWBCode: "AWB3", Description: "COD Charges Applied", DebitAmount: "35"},
+		{TransactionID: "t7", AWBCode: "AWB3", Description: "RTO Freight Charges Applied", DebitAmount: "90"},
+		// AWB4 was delivered per the ledger but carries an RTO charge.
+		{TransactionID: "t8", AWBCode: "AWB4", Description: "Freight Charges Applied", DebitAmount: "70"},
+		{TransactionID: "t9", AWBCode: "AWB4", Description: "RTO Freight Charges Applied", DebitAmount: "70"},
+		// AWB5 had its duplicate reversed.
+		{TransactionID: "t10", AWBCode: "AWB5", Description: "Freight Charges Applied", DebitAmount: "60"},
+		{TransactionID: "t11", AWBCode: "AWB5", Description: "Freight Charges Applied", DebitAmount: "60"},
+		{TransactionID: "t12", AWBCode: "AWB5", Description: "Freight Charges Reversed", CreditAmount: "60"},
+		// AWB9 is not ours.
+		{TransactionID: "t13", AWBCode: "AWB9", Description: "Freight Charges Applied", DebitAmount: "55"},
+	}
+	expected := []Shipment{
+		{AWB: "AWB1", OrderID: "ORD-1", Weight: 0.5, Freight: account.Rupees(80)},
+		{AWB: "AWB2", OrderID: "ORD-2", Weight: 1, Freight: account.Rupees(120)},
+		{AWB: "AWB3", OrderID: "ORD-3", Freight: account.Rupees(90), COD: account.Rupees(35), RTO: true, RTOCharge: account.Rupees(90)},
+		{AWB: "AWB4", OrderID: "ORD-4", Freight: account.Rupees(70)},
+		{AWB: "AWB5", OrderID: "ORD-5", Freight: account.Rupees(60)},
+	}
+
+	report, err := Reconcile(entries, expected)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+
+	type want struct {
+		kind   FindingKind
+		awb    string
+		amount account.Money
+	}
+	wants := []want{
+		{FindingDuplicateDebit, "AWB2", account.Rupees(120)},
+		{FindingOvercharge, "AWB2", account.Rupees(40)},
+		{FindingUnreturnedCOD, "AWB3", account.Rupees(35)},
+		{FindingRTOCharge, "AWB4", account.Rupees(70)},
+		{FindingUnknownAWB, "AWB9", account.Rupees(55)},
+	}
+	if len(report.Findings) != len(wants) {
+		t.Fatalf("expected %d findings, got %+v", len(wants), report.Findings)
+	}
+	for i, w := range wants {
+		got := report.Findings[i]
+		if got.Kind != w.kind || got.AWB != w.awb || got.Amount != w.amount {
+			t.Fatalf("finding %d: expected %+v, got %+v", i, w, got)
+		}
+	}
+	if report.Findings[1].OrderID != "ORD-2" || report.Findings[1].Detail != "freight above ledger; charged weight 1.5 kg vs 1 kg" {
+		t.Fatalf("unexpected overcharge finding %+v", report.Findings[1])
+	}
+	if report.TotalDebits != account.Rupees(890) || report.TotalCredits != account.Rupees(5060) {
+		t.Fatalf("unexpected totals debits=%v credits=%v", report.TotalDebits, report.TotalCredits)
+	}
+	if len(report.Shipments) != 6 || report.Shipments[1].Freight != account.Rupees(280) || report.Shipments[1].Net() != account.Rupees(280) {
+		t.Fatalf("unexpected shipment totals %+v", report.Shipments)
+	}
+	if report.Recoverable() != account.Rupees(320) {
+		t.Fatalf("expected 320 recoverable, got %v", report.Recoverable())
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV returned error: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("read csv: %v", err)
+	}
+	if len(rows) != 6 || rows[0][0] != "kind" || rows[1][0] != "duplicate_debit" || rows[1][3] != "t2;t3" || rows[1][6] != "120.00" {
+		t.Fatalf("unexpected csv %v", rows)
+	}
+}
+
+func TestReconcileKeepsReusedTransactionIDs(t *testing.T) {
+	entries := []account.StatementEntry{
+		{TransactionID: "t1", AWBCode: "AWB1", Description: "Freight Charges Applied", DebitAmount: "80.00", CreatedAt: "2024-01-02 10:00:00"},
+		// The same page fetched twice.
+		{TransactionID: "t1", AWBCode: "AWB1", Description: "Freight Charges Applied", DebitAmount: "80.00", CreatedAt: "2024-01-02 10:00:00"},
+		// A second debit that reuses the transaction ID.
+		{TransactionID: "t1", AWBCode: "AWB1", Description: "Freight Charges Applied", DebitAmount: "80.00", CreatedAt: "2024-01-05 09:30:00"},
+	}
+
+	report, err := Reconcile(entries, []Shipment{{AWB: "AWB1", Freight: account.Rupees(80)}})
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if report.TotalDebits != account.Rupees(160) {
+		t.Fatalf("expected 160 debited, got %v", report.TotalDebits)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Kind != FindingDuplicateDebit || report.Findings[0].Amount != account.Rupees(80) {
+		t.Fatalf("expected one duplicate debit of 80, got %+v", report.Findings)
+	}
+}
+
+func TestReconcileParsesSignedAmountsAndRejectsUnparseableOnes(t *testing.T) {
+	report, err := Reconcile([]account.StatementEntry{
+		{TransactionID: "t1", Description: "Adjustment", DebitAmount: "-Rs 12"},
+		{TransactionID: "t2", Description: "Adjustment", DebitAmount: "-₹ 12.50"},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Reconcile returned error: %v", err)
+	}
+	if report.TotalDebits != account.Rupees(-24.5) {
+		t.Fatalf("expected -24.50 debited, got %s", report.TotalDebits)
+	}
+
+	_, err = Reconcile([]account.StatementEntry{{AWBCode: "AWB1", DebitAmount: "eighty"}}, nil)
+	if err == nil {
+		t.Fatal("expected parse error")
+	}
+}
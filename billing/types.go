@@ -0,0 +1,86 @@
+package billing
+
+import "github.com/Niyantra-Labs/shiprocket-gosdk/account"
+
+// Shipment is one entry from your own ledger: what a shipment should have
+// cost and how it ended.
+type Shipment struct {
+	AWB     string
+	OrderID string
+	// Weight is the weight, in kg, Shiprocket should have charged for.
+	Weight float64
+	// Freight is the expected forward freight, including any weight
+	// discrepancy charges you have accepted.
+	Freight account.Money
+	// COD is the expected COD charge. Zero for prepaid shipments.
+	COD account.Money
+	// RTO marks shipments that were returned to origin. Their COD charge
+	// should be reversed and an RTO freight charge is expected.
+	RTO bool
+	// RTOCharge is the expected RTO freight. Zero accepts any RTO charge on
+	// an RTO shipment.
+	RTOCharge account.Money
+}
+
+type FindingKind string
+
+const (
+	// FindingOvercharge is a freight or weight charge above the ledger.
+	FindingOvercharge FindingKind = "overcharge"
+	// FindingDuplicateDebit is the same charge debited more than once.
+	FindingDuplicateDebit FindingKind = "duplicate_debit"
+	// FindingUnreturnedCOD is a COD charge not reversed after an RTO.
+	FindingUnreturnedCOD FindingKind = "unreturned_cod"
+	// FindingRTOCharge is an RTO charge on a shipment the ledger does not
+	// show as returned, or above the expected RTO charge.
+	FindingRTOCharge FindingKind = "rto_charge"
+	// FindingUnknownAWB is a debit for an AWB missing from the ledger.
+	FindingUnknownAWB FindingKind = "unknown_awb"
+)
+
+// Finding is one discrepancy for finance to act on. Amount is the sum to
+// dispute or recover. A weight finding without a charge above the ledger
+// has no amounts and names the weights in Detail.
+type Finding struct {
+	Kind           FindingKind
+	AWB            string
+	OrderID        string
+	TransactionIDs []string
+	Expected       account.Money
+	Actual         account.Money
+	Amount         account.Money
+	Detail         string
+}
+
+// ShipmentCharges totals the statement entries for one AWB.
+type ShipmentCharges struct {
+	AWB           string
+	OrderID       string
+	Freight       account.Money
+	COD           account.Money
+	RTO           account.Money
+	Other         account.Money
+	Credits       account.Money
+	ChargedWeight float64
+}
+
+// Net is the amount debited for the shipment after credits.
+func (c ShipmentCharges) Net() account.Money {
+	return c.Freight + c.COD + c.RTO + c.Other - c.Credits
+}
+
+type Report struct {
+	Findings     []Finding
+	Shipments    []ShipmentCharges
+	TotalDebits  account.Money
+	TotalCredits account.Money
+}
+
+// Recoverable is the total amount of all findings.
+func (r *Report) Recoverable() account.Money {
+	var total account.Money
+	for _, finding := range r.Findings {
+		total += finding.Amount
+	}
+	return total
+}
@@ -0,0 +1,292 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pagination"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/products"
+)
+
+var (
+	ErrSKURequired  = errors.New("product SKU is required")
+	ErrNameRequired = errors.New("product name is required")
+	ErrDuplicateSKU = errors.New("duplicate product SKU")
+)
+
+const (
+	defaultPerPage     = 100
+	defaultProductType = "Single"
+)
+
+type Service struct {
+	products *products.Service
+}
+
+func NewService(client *internalclient.Client) *Service {
+	return &Service{products: products.NewService(client)}
+}
+
+// Sync brings Shiprocket's products in line with source, matching them by
+// SKU. It plans the changes with Plan and, unless options.DryRun is set,
+// makes them with Apply. Updates are only made with options.ApplyUpdates. The returned plan records the outcome of each
+// change; an error is only returned when the plan could not be built or the
+// context ended.
+func (s *Service) Sync(ctx context.Context, source []Product, options *SyncOptions, opts ...internalclient.CallOption) (*Plan, error) {
+	plan, err := s.Plan(ctx, source, options, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if options != nil && options.DryRun {
+		return plan, nil
+	}
+	return plan, s.Apply(ctx, plan, opts...)
+}
+
+// Plan lists every Shiprocket product and compares the name, HSN,
+// dimensions, weight, MRP and image of each SKU in source. Nothing is
+// changed.
+func (s *Service) Plan(ctx context.Context, source []Product, options *SyncOptions, opts ...internalclient.CallOption) (*Plan, error) {
+	if err := validateSource(source); err != nil {
+		return nil, err
+	}
+	perPage := defaultPerPage
+	if options != nil && options.PerPage > 0 {
+		perPage = options.PerPage
+	}
+	current, err := pagination.Collect(ctx, s.products.ListIterator(&products.ListParams{PerPage: perPage}, opts...))
+	if err != nil {
+		return nil, err
+	}
+	plan := diff(source, current)
+	plan.ApplyUpdates = options != nil && options.ApplyUpdates
+	return plan, nil
+}
+
+// Apply makes the creates in plan one at a time, and the updates too when
+// plan.ApplyUpdates is set. A failed change is recorded in its Err and does
+// not stop the others. Changes already applied are skipped, so a plan can be
+// applied again to retry its failures.
+func (s *Service) Apply(ctx context.Context, plan *Plan, opts ...internalclient.CallOption) error {
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if change.Request == nil || change.Applied || (change.Kind == ChangeUpdate && !plan.ApplyUpdates) {
+			continue
+		}
+		_, err := s.products.Create(ctx, change.Request, opts...)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		change.Err = err
+		change.Applied = err == nil
+	}
+	return nil
+}
+
+func validateSource(source []Product) error {
+	seen := make(map[string]bool, len(source))
+	for i, product := range source {
+		sku := strings.TrimSpace(product.SKU)
+		if sku == "" {
+			return fmt.Errorf("product %d: %w", i+1, ErrSKURequired)
+		}
+		if strings.TrimSpace(product.Name) == "" {
+			return fmt.Errorf("product %q: %w", sku, ErrNameRequired)
+		}
+		if seen[sku] {
+			return fmt.Errorf("%w: %q", ErrDuplicateSKU, sku)
+		}
+		seen[sku] = true
+	}
+	return nil
+}
+
+// diff matches SKUs exactly after trimming spaces. When Shiprocket holds the
+// same SKU more than once, the first listed product is compared and the
+// other copies are reported as duplicates.
+func diff(source []Product, current []products.Summary) *Plan {
+	bySKU := make(map[string]products.Summary, len(current))
+	var duplicates []Change
+	for _, product := range current {
+		sku := strings.TrimSpace(product.SKU)
+		if _, ok := bySKU[sku]; ok {
+			duplicates = append(duplicates, Change{Kind: ChangeDuplicate, SKU: sku, ProductID: product.ID})
+			continue
+		}
+		bySKU[sku] = product
+	}
+
+	plan := &Plan{}
+	wanted := make(map[string]bool, len(source))
+	for _, product := range source {
+		sku := strings.TrimSpace(product.SKU)
+		wanted[sku] = true
+		existing, ok := bySKU[sku]
+		if !ok {
+			plan.Changes = append(plan.Changes, Change{Kind: ChangeCreate, SKU: sku, Request: createRequest(product)})
+			continue
+		}
+		diffs := compare(existing, product)
+		if len(diffs) == 0 {
+			plan.Unchanged++
+			continue
+		}
+		plan.Changes = append(plan.Changes, Change{
+			Kind:      ChangeUpdate,
+			SKU:       sku,
+			ProductID: existing.ID,
+			Diffs:     diffs,
+			Request:   updateRequest(existing, product),
+		})
+	}
+
+	reported := map[string]bool{}
+	for _, product := range current {
+		sku := strings.TrimSpace(product.SKU)
+		if wanted[sku] || reported[sku] {
+			continue
+		}
+		reported[sku] = true
+		plan.Changes = append(plan.Changes, Change{Kind: ChangeOrphan, SKU: sku, ProductID: product.ID})
+	}
+	plan.Changes = append(plan.Changes, duplicates...)
+	return plan
+}
+
+func compare(current products.Summary, desired Product) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field, current, desired string) {
+		diffs = append(diffs, FieldDiff{Field: field, Current: current, Desired: desired})
+	}
+
+	if name := strings.TrimSpace(desired.Name); name != strings.TrimSpace(current.Name) {
+		add("name", current.Name, name)
+	}
+	if hsn := strings.TrimSpace(desired.HSN); hsn != "" && hsn != strings.TrimSpace(current.HSN) {
+		add("hsn", current.HSN, hsn)
+	}
+	if hasDimensions(desired) {
+		length, width, height, ok := products.ParseDimensions(current.Dimensions)
+		if !ok || !near(length, desired.Length, 0.01) || !near(width, desired.Width, 0.01) || !near(height, desired.Height, 0.01) {
+			add("dimensions", current.Dimensions, formatDimensions(desired))
+		}
+	}
+	if desired.Weight > 0 {
+		weight, err := strconv.ParseFloat(strings.TrimSpace(current.Weight.String()), 64)
+		if err != nil || !near(weight, desired.Weight, 0.0005) {
+			add("weight", current.Weight.String(), formatNumber(desired.Weight))
+		}
+	}
+	if desired.MRP > 0 {
+		mrp, err := strconv.ParseFloat(strings.TrimSpace(current.MRP.String()), 64)
+		if err != nil || math.Round(mrp*100) != math.Round(desired.MRP*100) {
+			add("mrp", current.MRP.String(), formatNumber(desired.MRP))
+		}
+	}
+	if image := strings.TrimSpace(desired.ImageURL); image != "" && image != strings.TrimSpace(current.Image) {
+		add("image", current.Image, image)
+	}
+	return diffs
+}
+
+func createRequest(product Product) *products.CreateRequest {
+	productType := product.Type
+	if productType == "" {
+		productType = defaultProductType
+	}
+	request := &products.CreateRequest{
+		Name:         strings.TrimSpace(product.Name),
+		CategoryCode: product.CategoryCode,
+		Type:         productType,
+		Qty:          products.FlexibleString(strconv.FormatInt(product.Quantity, 10)),
+		SKU:          strings.TrimSpace(product.SKU),
+	}
+	overlay(request, product)
+	return request
+}
+
+// updateRequest resends the Shiprocket product with the source values on
+// top. Shiprocket documents no product update endpoint, so updates go
+// through the add product endpoint with the existing SKU. Shiprocket's API
+// documentation does not confirm that this updates the product in place
+// rather than rejecting the SKU. qty is left out so an update never
+// overwrites stock that moved since the plan was built.
+func updateRequest(current products.Summary, product Product) *products.CreateRequest {
+	request := &products.CreateRequest{
+		Name:         current.Name,
+		CategoryCode: current.CategoryCode,
+		Type:         current.Type,
+		SKU:          strings.TrimSpace(current.SKU),
+		HSN:          current.HSN,
+		TaxCode:      current.TaxCode,
+		Description:  current.Description,
+		Brand:        current.Brand,
+		Size:         current.Size,
+		Weight:       current.Weight,
+		EAN:          current.EAN,
+		UPC:          current.UPC,
+		ISBN:         current.ISBN,
+		Color:        current.Color,
+		ImageURL:     current.Image,
+		CostPrice:    current.CostPrice,
+		MRP:          current.MRP,
+	}
+	if length, width, height, ok := products.ParseDimensions(current.Dimensions); ok {
+		request.Length = products.FlexibleString(formatNumber(length))
+		request.Width = products.FlexibleString(formatNumber(width))
+		request.Height = products.FlexibleString(formatNumber(height))
+	}
+	if request.Type == "" {
+		request.Type = defaultProductType
+	}
+	if product.CategoryCode != "" {
+		request.CategoryCode = product.CategoryCode
+	}
+	if product.Type != "" {
+		request.Type = product.Type
+	}
+	request.Name = strings.TrimSpace(product.Name)
+	overlay(request, product)
+	return request
+}
+
+func overlay(request *products.CreateRequest, product Product) {
+	if hsn := strings.TrimSpace(product.HSN); hsn != "" {
+		request.HSN = hsn
+	}
+	if hasDimensions(product) {
+		request.Length = products.FlexibleString(formatNumber(product.Length))
+		request.Width = products.FlexibleString(formatNumber(product.Width))
+		request.Height = products.FlexibleString(formatNumber(product.Height))
+	}
+	if product.Weight > 0 {
+		request.Weight = products.FlexibleString(formatNumber(product.Weight))
+	}
+	if product.MRP > 0 {
+		request.MRP = products.FlexibleString(formatNumber(product.MRP))
+	}
+	if image := strings.TrimSpace(product.ImageURL); image != "" {
+		request.ImageURL = image
+	}
+}
+
+func hasDimensions(product Product) bool {
+	return product.Length > 0 || product.Width > 0 || product.Height > 0
+}
+
+func formatDimensions(product Product) string {
+	return formatNumber(product.Length) + "x" + formatNumber(product.Width) + "x" + formatNumber(product.Height)
+}
+
+func formatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func near(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
@@ -0,0 +1,145 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/products"
+)
+
+func TestSync(t *testing.T) {
+	var mu sync.Mutex
+	var created []products.CreateRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/external/products" {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		if r.Method == http.MethodPost {
+			body, _ := io.ReadAll(r.Body)
+			var request products.CreateRequest
+			if err := json.Unmarshal(body, &request); err != nil {
+				t.Fatalf("decode request: %v", err)
+			}
+			if request.SKU == "TEE-S" && bytes.Contains(body, []byte(`"qty"`)) {
+				t.Fatalf("update should not send qty: %s", body)
+			}
+			mu.Lock()
+			created = append(created, request)
+			mu.Unlock()
+			if request.SKU == "BROKEN" {
+				w.WriteHeader(http.StatusUnprocessableEntity)
+				_, _ = w.Write([]byte(`{"message":"The hsn must be 8 digits."}`))
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{}`))
+			return
+		}
+		if r.URL.Query().Get("per_page") != "2" {
+			t.Fatalf("unexpected query %s", r.URL.RawQuery)
+		}
+		switch r.URL.Query().Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":1,"sku":"TEE-S","name":"Tee Small","hsn":"6109","category_code":"apparel","type":"Single","weight":"0.25","dimensions":"20x15x2","mrp":"499.00","image":"https://cdn.example.com/tee.png","quantity":12,"brand":"Acme"},
+				{"id":2,"sku":"TEE-M","name":"Tee Medium","hsn":"6109","weight":"0.3","dimensions":"20 X 15 X 2","mrp":"499","quantity":"7"}
+			],"meta":{"pagination":{"total":4,"per_page":2,"current_page":1,"total_pages":2}}}`))
+		case "2":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":3,"sku":"OLD-MUG","name":"Mug","quantity":0},
+				{"id":4,"sku":"TEE-S ","name":"Tee Small copy","quantity":1}
+			],"meta":{"pagination":{"total":4,"per_page":2,"current_page":2,"total_pages":2}}}`))
+		default:
+			t.Fatalf("unexpected page %s", r.URL.Query().Get("page"))
+		}
+	}))
+	defer server.Close()
+
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	source := []Product{
+		{SKU: "TEE-S", Name: "Tee Small", HSN: "6109", Length: 20, Width: 15, Height: 2, Weight: 0.25, MRP: 549, ImageURL: "https://cdn.example.com/tee.png"},
+		{SKU: " TEE-M ", Name: "Tee Medium", HSN: "6109", Length: 20, Width: 15, Height: 2, Weight: 0.3, MRP: 499},
+		{SKU: "CAP", Name: "Cap", CategoryCode: "apparel", HSN: "6505", Weight: 0.1, MRP: 299, Quantity: 5},
+		{SKU: "BROKEN", Name: "Broken", HSN: "1"},
+	}
+
+	plan, err := s.Sync(context.Background(), source, &SyncOptions{DryRun: true, PerPage: 2})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(created) != 0 {
+		t.Fatalf("dry run created products: %+v", created)
+	}
+	if plan.Unchanged != 1 || len(plan.Creates()) != 2 || len(plan.Updates()) != 1 || len(plan.Orphans()) != 1 || !plan.HasChanges() {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+	update := plan.Updates()[0]
+	if update.SKU != "TEE-S" || update.ProductID != 1 || len(update.Diffs) != 1 || update.Diffs[0] != (FieldDiff{Field: "mrp", Current: "499.00", Desired: "549"}) {
+		t.Fatalf("unexpected update: %+v", update)
+	}
+	if request := update.Request; request.MRP != "549" || request.Qty != "" || request.Brand != "Acme" || request.CategoryCode != "apparel" || request.Length != "20" || request.Height != "2" {
+		t.Fatalf("update should keep Shiprocket's other fields: %+v", request)
+	}
+	if orphan := plan.Orphans()[0]; orphan.SKU != "OLD-MUG" || orphan.ProductID != 3 || orphan.Request != nil {
+		t.Fatalf("unexpected orphan: %+v", orphan)
+	}
+	if duplicates := plan.Duplicates(); len(duplicates) != 1 || duplicates[0].SKU != "TEE-S" || duplicates[0].ProductID != 4 || duplicates[0].Request != nil {
+		t.Fatalf("unexpected duplicates: %+v", duplicates)
+	}
+	if create := plan.Creates()[0].Request; create.SKU != "CAP" || create.Type != "Single" || create.Qty != "5" || create.Weight != "0.1" || create.MRP != "299" {
+		t.Fatalf("unexpected create: %+v", create)
+	}
+
+	if err := s.Apply(context.Background(), plan); err != nil {
+		t.Fatalf("apply: %v", err)
+	}
+	if len(created) != 2 || created[0].SKU != "CAP" || created[1].SKU != "BROKEN" {
+		t.Fatalf("expected only the creates without ApplyUpdates, got %+v", created)
+	}
+	if plan.Updates()[0].Applied {
+		t.Fatal("update was marked applied without ApplyUpdates")
+	}
+	failures := plan.Failures()
+	var validation *internalclient.ValidationError
+	if len(failures) != 1 || failures[0].SKU != "BROKEN" || !errors.As(failures[0].Err, &validation) {
+		t.Fatalf("unexpected failures: %+v", failures)
+	}
+
+	// Applying again with updates allowed sends the update and retries
+	// only the failure.
+	plan.ApplyUpdates = true
+	if err := s.Apply(context.Background(), plan); err != nil {
+		t.Fatalf("reapply: %v", err)
+	}
+	if len(created) != 4 || created[2].SKU != "TEE-S" || created[3].SKU != "BROKEN" || !plan.Updates()[0].Applied {
+		t.Fatalf("expected the update and the failed create, got %+v", created)
+	}
+}
+
+func TestSyncRejectsInvalidSource(t *testing.T) {
+	s := NewService(internalclient.New("http://127.0.0.1:0", internalclient.WithToken("secret")))
+	tests := []struct {
+		name   string
+		source []Product
+		want   error
+	}{
+		{name: "missing sku", source: []Product{{Name: "Cap"}}, want: ErrSKURequired},
+		{name: "missing name", source: []Product{{SKU: "CAP"}}, want: ErrNameRequired},
+		{name: "duplicate sku", source: []Product{{SKU: "CAP", Name: "Cap"}, {SKU: "CAP ", Name: "Cap"}}, want: ErrDuplicateSKU},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := s.Sync(context.Background(), tt.source, nil); !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package catalog
+
+import "github.com/Niyantra-Labs/shiprocket-gosdk/products"
+
+// Product is one product in your own catalogue. Zero values are not
+// managed: Sync neither compares them nor overwrites what Shiprocket has.
+type Product struct {
+	SKU          string
+	Name         string
+	HSN          string
+	CategoryCode string
+	// Type defaults to "Single" when the product is created.
+	Type string
+	// Length, Width and Height are in cm, Weight in kg and MRP in rupees.
+	Length   float64
+	Width    float64
+	Height   float64
+	Weight   float64
+	MRP      float64
+	ImageURL string
+	// Quantity is only sent when the product is created. Updates leave qty
+	// out of the request.
+	Quantity int64
+}
+
+type ChangeKind string
+
+const (
+	ChangeCreate ChangeKind = "create"
+	ChangeUpdate ChangeKind = "update"
+	// ChangeOrphan is a Shiprocket product whose SKU is not in the source.
+	// Orphans are reported but never deleted.
+	ChangeOrphan ChangeKind = "orphan"
+	// ChangeDuplicate is a further Shiprocket product with a SKU that an
+	// earlier listed product already has. Only the first copy is compared
+	// and updated; duplicates are reported but never changed.
+	ChangeDuplicate ChangeKind = "duplicate"
+)
+
+// FieldDiff is one field that differs between Shiprocket and the source.
+type FieldDiff struct {
+	Field   string
+	Current string
+	Desired string
+}
+
+// Change is one step of a sync plan. Request is what Apply sends and is nil
+// for orphans and duplicates. Applied and Err are set by Apply.
+type Change struct {
+	Kind      ChangeKind
+	SKU       string
+	ProductID int64
+	Diffs     []FieldDiff
+	Request   *products.CreateRequest
+	Applied   bool
+	Err       error
+}
+
+// Plan lists the changes needed to bring Shiprocket in line with the
+// source, in source order followed by orphans and duplicates.
+type Plan struct {
+	Changes   []Change
+	Unchanged int
+	// ApplyUpdates lets Apply send updates. It is copied from
+	// SyncOptions.ApplyUpdates; without it, Apply only creates products.
+	ApplyUpdates bool
+}
+
+func (p *Plan) Creates() []Change {
+	return p.filter(func(change Change) bool { return change.Kind == ChangeCreate })
+}
+
+func (p *Plan) Updates() []Change {
+	return p.filter(func(change Change) bool { return change.Kind == ChangeUpdate })
+}
+
+func (p *Plan) Orphans() []Change {
+	return p.filter(func(change Change) bool { return change.Kind == ChangeOrphan })
+}
+
+func (p *Plan) Duplicates() []Change {
+	return p.filter(func(change Change) bool { return change.Kind == ChangeDuplicate })
+}
+
+// Failures returns the changes Apply could not make.
+func (p *Plan) Failures() []Change {
+	return p.filter(func(change Change) bool { return change.Err != nil })
+}
+
+// HasChanges reports whether any product needs to be created or updated.
+func (p *Plan) HasChanges() bool {
+	for _, change := range p.Changes {
+		if change.Request != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Plan) filter(keep func(Change) bool) []Change {
+	var changes []Change
+	for _, change := range p.Changes {
+		if keep(change) {
+			changes = append(changes, change)
+		}
+	}
+	return changes
+}
+
+type SyncOptions struct {
+	// DryRun returns the plan without creating or updating anything.
+	DryRun bool
+	// ApplyUpdates resends changed products through the add product
+	// endpoint. Shiprocket does not document that endpoint as an update, so
+	// by default updates are only planned and Sync creates missing products.
+	ApplyUpdates bool
+	// PerPage is the page size used to list Shiprocket products. Defaults
+	// to 100.
+	PerPage int
+}
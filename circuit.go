@@ -0,0 +1,316 @@
+package shiprocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultCircuitWindow       = time.Minute
+	defaultCircuitMinRequests  = 10
+	defaultCircuitFailureRatio = 0.5
+	defaultCircuitOpenTimeout  = 30 * time.Second
+)
+
+// Endpoint groups used by CircuitGroup.
+const (
+	CircuitGroupAuth      = "auth"
+	CircuitGroupOrders    = "orders"
+	CircuitGroupCourier   = "courier"
+	CircuitGroupTracking  = "tracking"
+	CircuitGroupDocuments = "documents"
+	CircuitGroupOther     = "other"
+)
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerConfig struct {
+	// Group maps a request to its circuit. Defaults to CircuitGroup.
+	Group func(*http.Request) string
+	// Window is the period over which failures are counted while closed.
+	// Defaults to one minute.
+	Window time.Duration
+	// MinRequests is the number of requests a window needs before
+	// FailureRatio applies. Defaults to 10.
+	MinRequests int
+	// FailureRatio opens the circuit once this share of a window's requests
+	// failed. Defaults to 0.5.
+	FailureRatio float64
+	// ConsecutiveFailures opens the circuit after this many failures in a
+	// row, regardless of the window. Zero disables it.
+	ConsecutiveFailures int
+	// OpenTimeout is how long the circuit stays open before probing.
+	// Defaults to 30 seconds.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe requests let through while
+	// half-open. All of them must succeed to close the circuit. Defaults to 1.
+	HalfOpenProbes int
+	// IsFailure classifies a response. Defaults to transport errors,
+	// timeouts and 5xx responses; caller cancellations are ignored.
+	IsFailure func(*http.Response, error) bool
+	// OnStateChange is called after a circuit changes state.
+	OnStateChange func(group string, from, to CircuitState)
+}
+
+// CircuitBreaker keeps one circuit per endpoint group, so a degraded
+// tracking backend fails fast without starving order creation. Add it to
+// Config.Middleware with Middleware.
+type CircuitBreaker struct {
+	config CircuitBreakerConfig
+	now    func() time.Time
+
+	mu       sync.Mutex
+	circuits map[string]*circuit
+}
+
+type circuit struct {
+	state       CircuitState
+	generation  uint64
+	windowStart time.Time
+	requests    int
+	failures    int
+	consecutive int
+	openedAt    time.Time
+	probes      int
+	successes   int
+}
+
+func NewCircuitBreaker(cfg CircuitBreakerConfig) *CircuitBreaker {
+	if cfg.Group == nil {
+		cfg.Group = CircuitGroup
+	}
+	if cfg.Window <= 0 {
+		cfg.Window = defaultCircuitWindow
+	}
+	if cfg.MinRequests <= 0 {
+		cfg.MinRequests = defaultCircuitMinRequests
+	}
+	if cfg.FailureRatio <= 0 {
+		cfg.FailureRatio = defaultCircuitFailureRatio
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultCircuitOpenTimeout
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = 1
+	}
+	if cfg.IsFailure == nil {
+		cfg.IsFailure = defaultCircuitFailure
+	}
+	return &CircuitBreaker{config: cfg, now: time.Now, circuits: map[string]*circuit{}}
+}
+
+func (b *CircuitBreaker) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			group := b.config.Group(req)
+			generation, probe, err := b.allow(group)
+			if err != nil {
+				closeRequestBody(req)
+				return nil, err
+			}
+			resp, err := next.RoundTrip(req)
+			if errors.Is(err, context.Canceled) && req.Context().Err() != nil {
+				b.release(group, generation, probe)
+				return resp, err
+			}
+			b.record(group, generation, probe, b.config.IsFailure(resp, err))
+			return resp, err
+		})
+	}
+}
+
+// State reports the current state of a group's circuit.
+func (b *CircuitBreaker) State(group string) CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	c, ok := b.circuits[group]
+	if !ok {
+		return CircuitClosed
+	}
+	if c.state == CircuitOpen && !b.now().Before(c.openedAt.Add(b.config.OpenTimeout)) {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+// allow admits a request and returns the circuit generation it belongs to,
+// so results that arrive after a state change are ignored.
+func (b *CircuitBreaker) allow(group string) (uint64, bool, error) {
+	b.mu.Lock()
+	c := b.circuit(group)
+	now := b.now()
+	var changed func()
+
+	if c.state == CircuitOpen {
+		reopen := c.openedAt.Add(b.config.OpenTimeout)
+		if now.Before(reopen) {
+			b.mu.Unlock()
+			return 0, false, &CircuitOpenError{Group: group, RetryAfter: reopen.Sub(now)}
+		}
+		changed = b.transition(group, c, CircuitHalfOpen, now)
+	}
+
+	probe := false
+	if c.state == CircuitHalfOpen {
+		if c.probes+c.successes >= b.config.HalfOpenProbes {
+			b.mu.Unlock()
+			return 0, false, &CircuitOpenError{Group: group}
+		}
+		c.probes++
+		probe = true
+	} else if now.Sub(c.windowStart) >= b.config.Window {
+		c.windowStart, c.requests, c.failures = now, 0, 0
+	}
+	generation := c.generation
+	b.mu.Unlock()
+
+	if changed != nil {
+		changed()
+	}
+	return generation, probe, nil
+}
+
+func (b *CircuitBreaker) record(group string, generation uint64, probe, failed bool) {
+	b.mu.Lock()
+	c := b.circuit(group)
+	now := b.now()
+	var changed func()
+
+	switch {
+	case c.generation != generation:
+	case probe:
+		c.probes--
+		if failed {
+			changed = b.transition(group, c, CircuitOpen, now)
+			break
+		}
+		c.successes++
+		if c.successes >= b.config.HalfOpenProbes {
+			changed = b.transition(group, c, CircuitClosed, now)
+		}
+	case c.state == CircuitClosed:
+		c.requests++
+		if !failed {
+			c.consecutive = 0
+			break
+		}
+		c.failures++
+		c.consecutive++
+		tripped := c.requests >= b.config.MinRequests && float64(c.failures)/float64(c.requests) >= b.config.FailureRatio
+		if b.config.ConsecutiveFailures > 0 && c.consecutive >= b.config.ConsecutiveFailures {
+			tripped = true
+		}
+		if tripped {
+			changed = b.transition(group, c, CircuitOpen, now)
+		}
+	}
+	b.mu.Unlock()
+
+	if changed != nil {
+		changed()
+	}
+}
+
+// release frees a probe slot for a request the caller abandoned.
+func (b *CircuitBreaker) release(group string, generation uint64, probe bool) {
+	if !probe {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if c := b.circuit(group); c.generation == generation && c.probes > 0 {
+		c.probes--
+	}
+}
+
+func (b *CircuitBreaker) circuit(group string) *circuit {
+	c, ok := b.circuits[group]
+	if !ok {
+		c = &circuit{windowStart: b.now()}
+		b.circuits[group] = c
+	}
+	return c
+}
+
+// transition must be called with b.mu held. It returns the state change
+// callback so it can run after the lock is released.
+func (b *CircuitBreaker) transition(group string, c *circuit, to CircuitState, now time.Time) func() {
+	from := c.state
+	c.state = to
+	c.generation++
+	c.probes, c.successes = 0, 0
+	switch to {
+	case CircuitOpen:
+		c.openedAt = now
+	case CircuitClosed:
+		c.windowStart, c.requests, c.failures, c.consecutive = now, 0, 0, 0
+	}
+	if b.config.OnStateChange == nil || from == to {
+		return nil
+	}
+	return func() { b.config.OnStateChange(group, from, to) }
+}
+
+func defaultCircuitFailure(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+	return resp.StatusCode >= http.StatusInternalServerError
+}
+
+// CircuitGroup maps a request to auth, orders, courier, tracking, documents
+// or other, using the operation's path template when available.
+func CircuitGroup(req *http.Request) string {
+	path := req.URL.Path
+	if op, ok := OperationFromContext(req.Context()); ok && op.PathTemplate != "" {
+		path = op.PathTemplate
+	}
+	path = strings.TrimPrefix(path, "/v1/external")
+
+	switch {
+	case strings.HasPrefix(path, "/auth/"):
+		return CircuitGroupAuth
+	case strings.Contains(path, "/track"):
+		return CircuitGroupTracking
+	case strings.Contains(path, "/label"), strings.Contains(path, "/invoice"), strings.Contains(path, "/manifests/"):
+		return CircuitGroupDocuments
+	case strings.HasPrefix(path, "/courier/"), strings.Contains(path, "/courier/"):
+		return CircuitGroupCourier
+	case strings.HasPrefix(path, "/orders"), strings.HasPrefix(path, "/international/orders"):
+		return CircuitGroupOrders
+	default:
+		return CircuitGroupOther
+	}
+}
+
+// closeRequestBody closes the body of a request that middleware rejects
+// without calling next, as a RoundTripper must.
+func closeRequestBody(req *http.Request) {
+	if req.Body != nil {
+		_ = req.Body.Close()
+	}
+}
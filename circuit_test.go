@@ -0,0 +1,240 @@
+package shiprocket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerOpensPerGroupAndRecoversThroughProbes(t *testing.T) {
+	var trackingHealthy atomic.Bool
+	var trackingCalls, orderCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/track/") {
+			trackingCalls.Add(1)
+			if !trackingHealthy.Load() {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+		} else {
+			orderCalls.Add(1)
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var changes []string
+	now := time.Unix(1_700_000_000, 0)
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{
+		ConsecutiveFailures: 3,
+		OpenTimeout:         10 * time.Second,
+		OnStateChange: func(group string, from, to CircuitState) {
+			mu.Lock()
+			defer mu.Unlock()
+			changes = append(changes, fmt.Sprintf("%s:%s->%s", group, from, to))
+		},
+	})
+	breaker.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", Middleware: []Middleware{breaker.Middleware()}})
+
+	track := func() error {
+		return client.Do(context.Background(), &Request{
+			Operation:  "shipment.TrackByAWB",
+			Method:     http.MethodGet,
+			Path:       "/v1/external/courier/track/awb/{awb}",
+			PathParams: map[string]string{"awb": "AWB1"},
+		}, nil)
+	}
+	listOrders := func() error {
+		return client.Do(context.Background(), &Request{Operation: "orders.GetOrders", Method: http.MethodGet, Path: "/v1/external/orders"}, nil)
+	}
+
+	for i := 0; i < 3; i++ {
+		var serverErr *ServerError
+		if err := track(); !errors.As(err, &serverErr) {
+			t.Fatalf("expected server error, got %v", err)
+		}
+	}
+	if breaker.State(CircuitGroupTracking) != CircuitOpen {
+		t.Fatalf("expected tracking circuit to be open, got %s", breaker.State(CircuitGroupTracking))
+	}
+
+	var circuitErr *CircuitOpenError
+	if err := track(); !errors.As(err, &circuitErr) || circuitErr.Group != CircuitGroupTracking || circuitErr.RetryAfter != 10*time.Second {
+		t.Fatalf("expected CircuitOpenError, got %#v", err)
+	}
+	if trackingCalls.Load() != 3 {
+		t.Fatalf("expected open circuit to skip the server, got %d calls", trackingCalls.Load())
+	}
+	if err := listOrders(); err != nil || orderCalls.Load() != 1 {
+		t.Fatalf("expected orders group to stay closed, got err=%v calls=%d", err, orderCalls.Load())
+	}
+
+	mu.Lock()
+	now = now.Add(10 * time.Second)
+	mu.Unlock()
+	if err := track(); err == nil {
+		t.Fatal("expected failed probe")
+	}
+	if err := track(); !errors.As(err, &circuitErr) {
+		t.Fatalf("expected failed probe to reopen the circuit, got %v", err)
+	}
+
+	mu.Lock()
+	now = now.Add(10 * time.Second)
+	mu.Unlock()
+	trackingHealthy.Store(true)
+	if err := track(); err != nil {
+		t.Fatalf("expected successful probe, got %v", err)
+	}
+	if breaker.State(CircuitGroupTracking) != CircuitClosed {
+		t.Fatalf("expected circuit to close, got %s", breaker.State(CircuitGroupTracking))
+	}
+
+	want := []string{
+		"tracking:closed->open",
+		"tracking:open->half-open",
+		"tracking:half-open->open",
+		"tracking:open->half-open",
+		"tracking:half-open->closed",
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if strings.Join(changes, ",") != strings.Join(want, ",") {
+		t.Fatalf("unexpected state changes %v", changes)
+	}
+}
+
+func TestCircuitBreakerFailureRatioAndRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1)%2 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{MinRequests: 4, FailureRatio: 0.5})
+	client := NewClient(Config{
+		BaseURL:    server.URL,
+		Token:      "token",
+		Middleware: []Middleware{breaker.Middleware()},
+		Retry:      &RetryPolicy{MaxAttempts: 5, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond},
+	})
+
+	request := func() error {
+		return client.Do(context.Background(), &Request{Operation: "courier.CheckServiceability", Method: http.MethodGet, Path: "/v1/external/courier/serviceability/"}, nil)
+	}
+	if err := request(); err != nil {
+		t.Fatalf("first request: %v", err)
+	}
+	if err := request(); err != nil {
+		t.Fatalf("second request should succeed after one retry: %v", err)
+	}
+
+	// The fourth attempt fails, tripping the circuit at 2 failures in 4
+	// requests, and the retry that follows is rejected without a call.
+	var circuitErr *CircuitOpenError
+	if err := request(); !errors.As(err, &circuitErr) {
+		t.Fatalf("expected CircuitOpenError, got %v", err)
+	}
+	if calls.Load() != 4 {
+		t.Fatalf("expected 4 calls to the server, got %d", calls.Load())
+	}
+	if breaker.State(CircuitGroupCourier) != CircuitOpen {
+		t.Fatalf("expected courier circuit to be open, got %s", breaker.State(CircuitGroupCourier))
+	}
+}
+
+func TestCircuitGroup(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/v1/external/auth/login", want: CircuitGroupAuth},
+		{path: "/v1/external/orders/create/adhoc", want: CircuitGroupOrders},
+		{path: "/v1/external/international/orders/create/adhoc", want: CircuitGroupOrders},
+		{path: "/v1/external/courier/serviceability/", want: CircuitGroupCourier},
+		{path: "/v1/external/courier/assign/awb", want: CircuitGroupCourier},
+		{path: "/v1/external/courier/track/awb/{awb}", want: CircuitGroupTracking},
+		{path: "/v1/external/international/orders/track", want: CircuitGroupTracking},
+		{path: "/v1/external/courier/generate/label", want: CircuitGroupDocuments},
+		{path: "/v1/external/orders/print/invoice", want: CircuitGroupDocuments},
+		{path: "/v1/external/manifests/generate", want: CircuitGroupDocuments},
+		{path: "/v1/external/products", want: CircuitGroupOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "https://example.com"+strings.NewReplacer("{", "", "}", "").Replace(tt.path), nil)
+			if got := CircuitGroup(req); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerReleasesStreamedUploads(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{ConsecutiveFailures: 1, OpenTimeout: time.Hour})
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", Middleware: []Middleware{breaker.Middleware()}})
+	if _, err := client.Products.List(context.Background(), nil); err == nil {
+		t.Fatal("expected the first call to fail")
+	}
+	server.CloseClientConnections()
+
+	before := runtime.NumGoroutine()
+	var circuitErr *CircuitOpenError
+	for i := 0; i < 5; i++ {
+		_, err := client.Products.ImportFrom(context.Background(), "products.csv", strings.NewReader(strings.Repeat("sku,name\n", 1<<16)))
+		if !errors.As(err, &circuitErr) {
+			t.Fatalf("expected CircuitOpenError, got %v", err)
+		}
+	}
+
+	// Each upload's form writer must exit once the open circuit rejects it.
+	deadline := time.Now().Add(2 * time.Second)
+	for runtime.NumGoroutine() > before {
+		if time.Now().After(deadline) {
+			t.Fatalf("multipart writers leaked: %d goroutines, started with %d", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCircuitBreakerRejectsArtifactStreams(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	breaker := NewCircuitBreaker(CircuitBreakerConfig{ConsecutiveFailures: 1, OpenTimeout: time.Hour})
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", Middleware: []Middleware{breaker.Middleware()}})
+	if _, err := client.Products.List(context.Background(), nil); err == nil {
+		t.Fatal("expected the first call to fail")
+	}
+
+	_, err := client.Shipments.StreamArtifact(context.Background(), server.URL+"/exports/orders.csv", nil)
+	if _, ok := err.(*CircuitOpenError); !ok {
+		t.Fatalf("expected an unwrapped CircuitOpenError, got %T: %v", err, err)
+	}
+}
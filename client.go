@@ -2,17 +2,21 @@ package shiprocket
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"time"
 
 	"github.com/Niyantra-Labs/shiprocket-gosdk/account"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/auth"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/catalog"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/channels"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/documents"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/hyperlocal"
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/international"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/inventory"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/jobs"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/listings"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/location"
 	"github.com/Niyantra-Labs/shiprocket-gosdk/ndr"
@@ -29,21 +33,57 @@ type TokenSource = internalclient.TokenSource
 type Logger = internalclient.Logger
 type Hook = internalclient.Hook
 type Middleware = internalclient.Middleware
+type Observer = internalclient.Observer
+type Redactor = internalclient.Redactor
+type CallOption = internalclient.CallOption
+type RetryPolicy = internalclient.RetryPolicy
+type Operation = internalclient.Operation
+type OperationResult = internalclient.OperationResult
 type APIError = internalclient.APIError
 type ResponseMeta = internalclient.ResponseMeta
+type RateLimit = internalclient.RateLimit
 type TransportError = internalclient.TransportError
 type AuthError = internalclient.AuthError
 type RateLimitError = internalclient.RateLimitError
 type ValidationError = internalclient.ValidationError
 type BusinessError = internalclient.BusinessError
 type ServerError = internalclient.ServerError
+type CircuitOpenError = internalclient.CircuitOpenError
 type Request = internalclient.Request
 type MultipartBody = internalclient.MultipartBody
 type MultipartFile = internalclient.MultipartFile
 type Download = internalclient.Download
+type Stream = internalclient.Stream
+type StreamOptions = internalclient.StreamOptions
+type SavedFile = internalclient.SavedFile
 type LoginRequest = auth.LoginRequest
 type LoginResponse = auth.LoginResponse
 
+var ErrDownloadTooLarge = internalclient.ErrDownloadTooLarge
+
+// NoRetries disables retries for one call: WithRetryPolicy(NoRetries).
+var NoRetries = internalclient.NoRetries
+
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return internalclient.WithCallTimeout(timeout)
+}
+
+func WithHeader(key, value string) CallOption {
+	return internalclient.WithHeader(key, value)
+}
+
+func WithIdempotencyKey(key string) CallOption {
+	return internalclient.WithIdempotencyKey(key)
+}
+
+func WithRetryPolicy(policy *RetryPolicy) CallOption {
+	return internalclient.WithRetryPolicy(policy)
+}
+
+func CaptureResponseMeta(meta *ResponseMeta) CallOption {
+	return internalclient.CaptureResponseMeta(meta)
+}
+
 type Credentials struct {
 	Email    string
 	Password string
@@ -66,8 +106,13 @@ type Config struct {
 	Timeout     time.Duration
 	UserAgent   string
 	Logger      Logger
+	Slog        *slog.Logger
+	LogBodies   bool
+	Redactor    *Redactor
 	Hooks       []Hook
 	Middleware  []Middleware
+	Observers   []Observer
+	Retry       *RetryPolicy
 }
 
 type Client struct {
@@ -89,6 +134,9 @@ type Client struct {
 	Returns         *returns.Service
 	Shipments       *shipment.Service
 	NDR             *ndr.Service
+	Documents       *documents.Service
+	Jobs            *jobs.Service
+	Catalog         *catalog.Service
 }
 
 func NewClient(cfg Config) *Client {
@@ -112,12 +160,27 @@ func NewClient(cfg Config) *Client {
 	if cfg.Logger != nil {
 		opts = append(opts, internalclient.WithLogger(cfg.Logger))
 	}
+	if cfg.Slog != nil {
+		opts = append(opts, internalclient.WithSlog(cfg.Slog))
+	}
+	if cfg.LogBodies {
+		opts = append(opts, internalclient.WithLogBodies(true))
+	}
+	if cfg.Redactor != nil {
+		opts = append(opts, internalclient.WithRedactor(cfg.Redactor))
+	}
 	if len(cfg.Hooks) > 0 {
 		opts = append(opts, internalclient.WithHooks(cfg.Hooks...))
 	}
 	if len(cfg.Middleware) > 0 {
 		opts = append(opts, internalclient.WithMiddleware(cfg.Middleware...))
 	}
+	if cfg.Retry != nil {
+		opts = append(opts, internalclient.WithRetry(cfg.Retry))
+	}
+	if len(cfg.Observers) > 0 {
+		opts = append(opts, internalclient.WithObservers(cfg.Observers...))
+	}
 
 	core := internalclient.New(cfg.BaseURL, opts...)
 	if cfg.Token == "" && cfg.TokenSource == nil && cfg.Credentials != nil {
@@ -136,8 +199,13 @@ func NewClient(cfg Config) *Client {
 			Timeout:     core.HTTPClient.Timeout,
 			UserAgent:   core.UserAgent,
 			Logger:      cfg.Logger,
+			Slog:        cfg.Slog,
+			LogBodies:   cfg.LogBodies,
+			Redactor:    cfg.Redactor,
 			Hooks:       cfg.Hooks,
 			Middleware:  cfg.Middleware,
+			Observers:   cfg.Observers,
+			Retry:       cfg.Retry,
 		},
 	}
 	if managedTokenSource != nil {
@@ -167,6 +235,10 @@ func NewClient(cfg Config) *Client {
 	client.Returns = returns.NewService(core)
 	client.Shipments = shipment.NewService(core)
 	client.NDR = ndr.NewService(core)
+	client.Documents = documents.NewService(core)
+	client.Jobs = jobs.NewService(core)
+	client.Catalog = catalog.NewService(core)
+	client.Orders.SetImportChecker(client.Jobs.OrderImportChecker(nil))
 
 	return client
 }
@@ -178,6 +250,23 @@ func authTokenSource(core *internalclient.Client, credentials Credentials) Token
 	})
 }
 
+// WithResponseMeta makes every SDK call made with the returned context copy
+// the status, headers, request ID and rate-limit headers of its final
+// response into meta.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return internalclient.WithResponseMeta(ctx, meta)
+}
+
+// OperationFromContext returns the SDK operation of an outgoing request, such
+// as "shipment.TrackByAWB", with its path template and attempt number.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	return internalclient.OperationFromContext(ctx)
+}
+
+func OperationFromResponse(resp *http.Response) (Operation, bool) {
+	return internalclient.OperationFromResponse(resp)
+}
+
 func (c *Client) HTTPClient() *http.Client {
 	return c.core.HTTPClient
 }
@@ -186,18 +275,32 @@ func (c *Client) BaseURL() string {
 	return c.core.BaseURL
 }
 
-func (c *Client) Do(ctx context.Context, req *Request, out any) error {
-	return c.core.Do(ctx, req, out)
+func (c *Client) Do(ctx context.Context, req *Request, out any, opts ...CallOption) error {
+	return c.core.Do(ctx, req, out, opts...)
+}
+
+func (c *Client) DoRaw(ctx context.Context, req *Request, opts ...CallOption) (*http.Response, error) {
+	return c.core.DoRaw(ctx, req, opts...)
 }
 
-func (c *Client) DoRaw(ctx context.Context, req *Request) (*http.Response, error) {
-	return c.core.DoRaw(ctx, req)
+func (c *Client) DoBytes(ctx context.Context, req *Request, opts ...CallOption) ([]byte, error) {
+	return c.core.DoBytes(ctx, req, opts...)
 }
 
-func (c *Client) DoBytes(ctx context.Context, req *Request) ([]byte, error) {
-	return c.core.DoBytes(ctx, req)
+func (c *Client) DoDownload(ctx context.Context, req *Request, opts ...CallOption) (*Download, error) {
+	return c.core.DoDownload(ctx, req, opts...)
 }
 
-func (c *Client) DoDownload(ctx context.Context, req *Request) (*Download, error) {
-	return c.core.DoDownload(ctx, req)
+func (c *Client) DoStream(ctx context.Context, req *Request, opts *StreamOptions, callOpts ...CallOption) (*Stream, error) {
+	return c.core.DoStream(ctx, req, opts, callOpts...)
+}
+
+func (c *Client) SaveDownload(ctx context.Context, req *Request, path string, opts *StreamOptions, callOpts ...CallOption) (*SavedFile, error) {
+	return internalclient.SaveFile(path, func(offset int64, validator string) (*Stream, error) {
+		options := StreamOptions{Offset: offset, IfRange: validator}
+		if opts != nil {
+			options.MaxSize = opts.MaxSize
+		}
+		return c.core.DoStream(ctx, req, &options, callOpts...)
+	})
 }
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
 )
 
 type noopLogger struct{}
@@ -23,7 +27,7 @@ func TestNewClientBuildsRegisteredServicesAndDefaults(t *testing.T) {
 		},
 	})
 
-	if client.Auth == nil || client.Orders == nil || client.Couriers == nil || client.PickupAddresses == nil || client.Products == nil || client.Listings == nil || client.Channels == nil || client.Inventory == nil || client.Location == nil || client.International == nil || client.Hyperlocal == nil || client.Account == nil || client.Returns == nil || client.Shipments == nil || client.NDR == nil {
+	if client.Auth == nil || client.Orders == nil || client.Couriers == nil || client.PickupAddresses == nil || client.Products == nil || client.Listings == nil || client.Channels == nil || client.Inventory == nil || client.Location == nil || client.International == nil || client.Hyperlocal == nil || client.Account == nil || client.Returns == nil || client.Shipments == nil || client.NDR == nil || client.Documents == nil || client.Jobs == nil || client.Catalog == nil {
 		t.Fatal("expected registered services on client")
 	}
 	if client.BaseURL() != DefaultBaseURL {
@@ -99,6 +103,123 @@ func TestRootClientExposesSharedHTTPHelpers(t *testing.T) {
 	if download.FileName != "label.pdf" {
 		t.Fatalf("unexpected filename: %s", download.FileName)
 	}
+
+	var meta ResponseMeta
+	path := filepath.Join(t.TempDir(), "label.pdf")
+	if _, err := client.SaveDownload(context.Background(), &Request{
+		Method: http.MethodGet,
+		Path:   "/label",
+	}, path, nil, CaptureResponseMeta(&meta)); err != nil {
+		t.Fatalf("SaveDownload returned error: %v", err)
+	}
+	if saved, err := os.ReadFile(path); err != nil || string(saved) != "%PDF-label" {
+		t.Fatalf("unexpected saved file %q: %v", saved, err)
+	}
+	if meta.StatusCode != http.StatusOK {
+		t.Fatalf("expected SaveDownload to apply call options, got %+v", meta)
+	}
+}
+
+type operationHook struct {
+	before func(*http.Request)
+	after  func(*http.Response, error)
+}
+
+func (h operationHook) Before(req *http.Request)             { h.before(req) }
+func (h operationHook) After(resp *http.Response, err error) { h.after(resp, err) }
+
+func TestOperationMetadataReachesMiddlewareAndHooks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1}}`))
+	}))
+	defer server.Close()
+
+	var middlewareOps, beforeOps, afterOps []Operation
+	client := NewClient(Config{
+		BaseURL: server.URL,
+		Token:   "secret",
+		Middleware: []Middleware{func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				op, _ := OperationFromContext(req.Context())
+				middlewareOps = append(middlewareOps, op)
+				return next.RoundTrip(req)
+			})
+		}},
+		Hooks: []Hook{operationHook{
+			before: func(req *http.Request) {
+				op, _ := OperationFromContext(req.Context())
+				beforeOps = append(beforeOps, op)
+			},
+			after: func(resp *http.Response, err error) {
+				op, _ := OperationFromResponse(resp)
+				afterOps = append(afterOps, op)
+			},
+		}},
+	})
+
+	if _, err := client.Shipments.TrackByAWB(context.Background(), &shipment.TrackByAWBRequest{AWBCode: "141123221084922"}); err != nil {
+		t.Fatalf("TrackByAWB returned error: %v", err)
+	}
+	if _, err := client.Shipments.TrackByShipmentID(context.Background(), &shipment.TrackByShipmentIDRequest{ShipmentID: 16104408}); err != nil {
+		t.Fatalf("TrackByShipmentID returned error: %v", err)
+	}
+
+	want := []Operation{
+		{Name: "shipment.TrackByAWB", Method: http.MethodGet, PathTemplate: "/v1/external/courier/track/awb/{awb_code}", Attempt: 1},
+		{Name: "shipment.TrackByShipmentID", Method: http.MethodGet, PathTemplate: "/v1/external/courier/track/shipment/{shipment_id}", Attempt: 1},
+	}
+	for name, got := range map[string][]Operation{"middleware": middlewareOps, "before": beforeOps, "after": afterOps} {
+		if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+			t.Fatalf("unexpected %s operations: %+v", name, got)
+		}
+	}
+
+	if _, ok := OperationFromContext(context.Background()); ok {
+		t.Fatal("expected no operation outside SDK requests")
+	}
+	if _, ok := OperationFromResponse(nil); ok {
+		t.Fatal("expected no operation for a nil response")
+	}
+}
+
+func TestServiceMethodsAcceptCallOptions(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		if r.Header.Get("X-Trace") != "abc" {
+			t.Fatalf("missing per-call header: %v", r.Header)
+		}
+		w.Header().Set("X-Request-Id", "req-track")
+		_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(Config{
+		BaseURL: server.URL,
+		Token:   "secret",
+		Retry:   &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond},
+	})
+
+	var meta ResponseMeta
+	if _, err := client.Shipments.TrackByAWB(context.Background(), &shipment.TrackByAWBRequest{AWBCode: "141123221084922"},
+		WithHeader("X-Trace", "abc"),
+		CaptureResponseMeta(&meta),
+	); err != nil {
+		t.Fatalf("TrackByAWB returned error: %v", err)
+	}
+	if atomic.LoadInt32(&attempts) != 2 || meta.RequestID != "req-track" {
+		t.Fatalf("expected a retried call with captured meta, attempts=%d meta=%+v", attempts, meta)
+	}
+
+	atomic.StoreInt32(&attempts, 0)
+	_, err := client.Shipments.TrackByAWB(context.Background(), &shipment.TrackByAWBRequest{AWBCode: "141123221084922"}, WithRetryPolicy(NoRetries))
+	var serverErr *ServerError
+	if !errors.As(err, &serverErr) || atomic.LoadInt32(&attempts) != 1 {
+		t.Fatalf("expected a single failed attempt, got %v after %d attempts", err, attempts)
+	}
 }
 
 func TestRootClientExposesClassifiedErrors(t *testing.T) {
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/account"
+)
+
+func runAccountStatement(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("account statement", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "entries per page")
+	from := fs.String("from", "", "from date, YYYY-MM-DD")
+	to := fs.String("to", "", "to date, YYYY-MM-DD")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("account statement: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Account.GetStatement(ctx, &account.StatementParams{
+		Page:    *page,
+		PerPage: *perPage,
+		From:    *from,
+		To:      *to,
+	})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"TRANSACTION", "AWB", "DESCRIPTION", "DEBIT", "CREDIT", "BALANCE", "CREATED"}}
+	for _, entry := range response.Data {
+		view.rows = append(view.rows, []string{
+			entry.TransactionID,
+			entry.AWBCode,
+			entry.Description,
+			entry.DebitAmount,
+			entry.CreditAmount,
+			entry.BalanceAmount.String(),
+			entry.CreatedAt,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runAccountDiscrepancy(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("account discrepancy", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("account discrepancy: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Account.GetDiscrepancy(ctx)
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, recordTable(response.Data))
+}
+
+// recordTable flattens loosely typed records into a table. The discrepancy
+// endpoint has no documented row schema, so the columns are the union of
+// the keys seen, in sorted order.
+func recordTable(records []map[string]any) table {
+	seen := map[string]bool{}
+	var view table
+	for _, record := range records {
+		for key := range record {
+			if !seen[key] {
+				seen[key] = true
+				view.headers = append(view.headers, key)
+			}
+		}
+	}
+	sort.Strings(view.headers)
+
+	for _, record := range records {
+		row := make([]string, len(view.headers))
+		for i, key := range view.headers {
+			if value, ok := record[key]; ok && value != nil {
+				row[i] = fmt.Sprint(value)
+			}
+		}
+		view.rows = append(view.rows, row)
+	}
+	return view
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/auth"
+)
+
+// runAuthLogin exchanges the email and password for a token, so it can be
+// stored in a profile or SHIPROCKET_TOKEN.
+func runAuthLogin(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("auth login", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("auth login: unexpected arguments %v", positional)
+	}
+
+	selected, err := resolveProfile(opts, env.getenv)
+	if err != nil {
+		return err
+	}
+	if selected.Email == "" || selected.Password == "" {
+		return errNoCredentials
+	}
+	login := &auth.AuthService{
+		BaseURL:   selected.BaseURL,
+		Email:     selected.Email,
+		Password:  selected.Password,
+		UserAgent: "shiprocket-cli",
+	}
+	response, err := login.Login(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"TOKEN"}, rows: [][]string{{response.Token}}}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runAuthLogout(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("auth logout", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("auth logout: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	if err := client.Auth.Logout(ctx); err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"STATUS"}, rows: [][]string{{"logged out"}}}
+	return render(env.stdout, opts.output, map[string]string{"status": "logged out"}, view)
+}
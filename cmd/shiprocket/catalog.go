@@ -0,0 +1,147 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/inventory"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/listings"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/products"
+)
+
+func runProductsList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("products list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "products per page")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("products list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Products.List(ctx, &products.ListParams{Page: *page, PerPage: *perPage})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "SKU", "NAME", "CATEGORY", "MRP", "WEIGHT"}}
+	for _, product := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(product.ID, 10),
+			product.SKU,
+			product.Name,
+			product.CategoryName,
+			product.MRP.String(),
+			product.Weight.String(),
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runChannelsList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("channels list", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("channels list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Channels.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "NAME", "STATUS", "ORDERS SYNCED"}}
+	for _, channel := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(channel.ID, 10),
+			channel.Name,
+			channel.Status,
+			channel.OrdersSyncedOn,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runListingsList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("listings list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "listings per page")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("listings list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Listings.List(ctx, &listings.ListParams{Page: *page, PerPage: *perPage})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "SKU", "CHANNEL SKU", "CHANNEL", "TITLE", "INVENTORY"}}
+	for _, listing := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(listing.ID, 10),
+			listing.SKU,
+			listing.ChannelSKU,
+			listing.ChannelName,
+			listing.Title,
+			strconv.FormatInt(listing.Inventory.Int64(), 10),
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runInventoryList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("inventory list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "items per page")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("inventory list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Inventory.List(ctx, &inventory.ListParams{Page: *page, PerPage: *perPage})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "SKU", "NAME", "TOTAL", "AVAILABLE", "BLOCKED"}}
+	for _, item := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(item.ID, 10),
+			item.SKU,
+			item.Name,
+			strconv.FormatInt(item.TotalQuantity.Int64(), 10),
+			strconv.FormatInt(item.AvailableQuantity.Int64(), 10),
+			strconv.FormatInt(item.BlockedQuantity.Int64(), 10),
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
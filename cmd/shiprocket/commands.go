@@ -0,0 +1,501 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	shiprocket "github.com/Niyantra-Labs/shiprocket-gosdk"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/auth"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/documents"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/ndr"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/orders"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+)
+
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitAuth        = 3
+	exitValidation  = 4
+	exitBusiness    = 5
+	exitRateLimited = 6
+	exitServer      = 7
+	exitTransport   = 8
+)
+
+type environment struct {
+	stdout io.Writer
+	stderr io.Writer
+	getenv func(string) string
+}
+
+type globalOptions struct {
+	output  string
+	profile string
+	config  string
+}
+
+// register adds the global flags to fs. The current values are used as
+// defaults so flags parsed before the command are kept.
+func (o *globalOptions) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.output, "output", o.output, "output format: json, table or csv")
+	fs.StringVar(&o.profile, "profile", o.profile, "profile name in the config file")
+	fs.StringVar(&o.config, "config", o.config, "config file path")
+}
+
+type usageError struct {
+	message string
+}
+
+func (e *usageError) Error() string {
+	return e.message
+}
+
+func usagef(format string, args ...any) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+type command struct {
+	group   string
+	name    string
+	summary string
+	run     func(ctx context.Context, env *environment, opts *globalOptions, args []string) error
+}
+
+var commands = []command{
+	{group: "track", name: "awb", summary: "track a shipment by AWB: track awb <awb>", run: runTrackAWB},
+	{group: "track", name: "shipment", summary: "track a shipment by ID: track shipment <shipment-id>", run: runTrackShipment},
+	{group: "shipments", name: "list", summary: "list shipments: shipments list [--page 1]", run: runShipmentsList},
+	{group: "shipments", name: "get", summary: "show a shipment: shipments get <shipment-id>", run: runShipmentsGet},
+	{group: "shipments", name: "cancel", summary: "cancel shipments: shipments cancel <awb> [<awb>...]", run: runShipmentsCancel},
+	{group: "shipments", name: "manifest", summary: "generate a manifest: shipments manifest --shipment 1,2,3", run: runShipmentsManifest},
+	{group: "orders", name: "list", summary: "list orders: orders list [--status NEW] [--page 1] [--per-page 20]", run: runOrdersList},
+	{group: "ndr", name: "act", summary: "act on an NDR shipment: ndr act <awb> --action re-attempt|return|fake-attempt", run: runNDRAct},
+	{group: "labels", name: "download", summary: "download merged labels: labels download --shipment 1,2,3 -o labels.pdf", run: runLabelsDownload},
+	{group: "pickup", name: "list", summary: "list pickup locations: pickup list", run: runPickupList},
+	{group: "pickup", name: "generate", summary: "request a pickup: pickup generate --shipment 1,2,3", run: runPickupGenerate},
+	{group: "couriers", name: "serviceability", summary: "list couriers for a lane: couriers serviceability --pickup 110001 --delivery 400001 --weight 0.5 [--cod]", run: runCouriersServiceability},
+	{group: "couriers", name: "assign", summary: "assign an AWB: couriers assign <shipment-id> [--courier 10]", run: runCouriersAssign},
+	{group: "returns", name: "list", summary: "list return orders: returns list [--page 1] [--per-page 20]", run: runReturnsList},
+	{group: "products", name: "list", summary: "list products: products list [--page 1] [--per-page 20]", run: runProductsList},
+	{group: "channels", name: "list", summary: "list sales channels: channels list", run: runChannelsList},
+	{group: "listings", name: "list", summary: "list channel listings: listings list [--page 1] [--per-page 20]", run: runListingsList},
+	{group: "inventory", name: "list", summary: "list inventory: inventory list [--page 1] [--per-page 20]", run: runInventoryList},
+	{group: "international", name: "serviceability", summary: "list international couriers: international serviceability --country US --weight 0.5", run: runInternationalServiceability},
+	{group: "international", name: "track", summary: "track international orders: international track", run: runInternationalTrack},
+	{group: "hyperlocal", name: "serviceability", summary: "list hyperlocal couriers: hyperlocal serviceability --pickup 110001 --delivery 110002 --weight 0.5", run: runHyperlocalServiceability},
+	{group: "hyperlocal", name: "orders", summary: "list hyperlocal orders: hyperlocal orders [--page 1] [--per-page 20]", run: runHyperlocalOrders},
+	{group: "location", name: "countries", summary: "list countries: location countries", run: runLocationCountries},
+	{group: "location", name: "postcode", summary: "show postcode details: location postcode <postcode>", run: runLocationPostcode},
+	{group: "wallet", name: "balance", summary: "show the wallet balance: wallet balance", run: runWalletBalance},
+	{group: "account", name: "statement", summary: "show the account statement: account statement [--from 2024-01-01] [--to 2024-01-31]", run: runAccountStatement},
+	{group: "account", name: "discrepancy", summary: "list weight discrepancies: account discrepancy", run: runAccountDiscrepancy},
+	{group: "auth", name: "login", summary: "print a token for the configured credentials: auth login", run: runAuthLogin},
+	{group: "auth", name: "logout", summary: "invalidate the current token: auth logout", run: runAuthLogout},
+}
+
+func run(ctx context.Context, args []string, env *environment) int {
+	opts := &globalOptions{output: formatTable}
+	fs := flag.NewFlagSet("shiprocket", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	opts.register(fs)
+	if err := fs.Parse(args); err != nil {
+		return fail(env, usagef("%v", err))
+	}
+
+	rest := fs.Args()
+	if len(rest) == 0 || rest[0] == "help" {
+		printUsage(env.stderr)
+		if len(rest) == 0 {
+			return exitUsage
+		}
+		return exitOK
+	}
+	if len(rest) < 2 {
+		return fail(env, usagef("missing subcommand for %q", rest[0]))
+	}
+
+	for _, cmd := range commands {
+		if cmd.group == rest[0] && cmd.name == rest[1] {
+			return fail(env, cmd.run(ctx, env, opts, rest[2:]))
+		}
+	}
+	return fail(env, usagef("unknown command %q", rest[0]+" "+rest[1]))
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "usage: shiprocket [--output json|table|csv] [--profile name] [--config path] <command> <subcommand> [flags]")
+	fmt.Fprintln(w)
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-28s %s\n", cmd.group+" "+cmd.name, cmd.summary)
+	}
+}
+
+func fail(env *environment, err error) int {
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintf(env.stderr, "shiprocket: %v\n", err)
+	return exitCode(err)
+}
+
+// exitCode maps SDK errors onto stable process exit codes so scripts can
+// branch on the failure class.
+func exitCode(err error) int {
+	var (
+		usageErr      *usageError
+		authErr       *shiprocket.AuthError
+		validationErr *shiprocket.ValidationError
+		businessErr   *shiprocket.BusinessError
+		rateLimitErr  *shiprocket.RateLimitError
+		serverErr     *shiprocket.ServerError
+		transportErr  *shiprocket.TransportError
+	)
+	switch {
+	case errors.As(err, &usageErr):
+		return exitUsage
+	case errors.Is(err, errNoCredentials), errors.Is(err, auth.ErrCredentialsRequired), errors.As(err, &authErr):
+		return exitAuth
+	case errors.As(err, &validationErr):
+		return exitValidation
+	case errors.As(err, &businessErr):
+		return exitBusiness
+	case errors.As(err, &rateLimitErr):
+		return exitRateLimited
+	case errors.As(err, &serverErr):
+		return exitServer
+	case errors.As(err, &transportErr):
+		return exitTransport
+	default:
+		return exitError
+	}
+}
+
+// parseArgs parses flags that may appear before, between or after the
+// positional arguments and returns the positional arguments in order.
+// Everything after a "--" terminator is positional, even if it starts with
+// a dash.
+func parseArgs(fs *flag.FlagSet, opts *globalOptions, args []string) ([]string, error) {
+	fs.SetOutput(io.Discard)
+	opts.register(fs)
+
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, usagef("%s: %v", fs.Name(), err)
+		}
+		rest := fs.Args()
+		if consumed := len(args) - len(rest); consumed > 0 && args[consumed-1] == "--" {
+			positional = append(positional, rest...)
+			break
+		}
+		args = rest
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if !validFormat(opts.output) {
+		return nil, usagef("unsupported output format %q", opts.output)
+	}
+	return positional, nil
+}
+
+func clientFor(env *environment, opts *globalOptions) (*shiprocket.Client, error) {
+	selected, err := resolveProfile(opts, env.getenv)
+	if err != nil {
+		return nil, err
+	}
+	return newClient(selected)
+}
+
+func runTrackAWB(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("track awb", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("track awb: expected exactly one AWB")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.TrackByAWB(ctx, &shipment.TrackByAWBRequest{AWBCode: positional[0]})
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, trackingTable(response))
+}
+
+// trackingTable lists the scan history, or the shipment summary when
+// Shiprocket has no scans yet.
+func trackingTable(response *shipment.TrackingResponse) table {
+	view := table{headers: []string{"DATE", "STATUS", "ACTIVITY", "LOCATION"}}
+	for _, activity := range response.TrackingData.ShipmentTrackActivities {
+		status := activity.SRStatusLabel
+		if status == "" {
+			status = activity.Status
+		}
+		view.rows = append(view.rows, []string{activity.Date, status, activity.Activity, activity.Location})
+	}
+	if len(view.rows) == 0 {
+		view.headers = []string{"AWB", "COURIER", "STATUS", "ORIGIN", "DESTINATION"}
+		for _, track := range response.TrackingData.ShipmentTrack {
+			view.rows = append(view.rows, []string{track.AWBCode, track.CourierName, track.CurrentStatus, track.Origin, track.Destination})
+		}
+	}
+	return view
+}
+
+func runOrdersList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("orders list", flag.ContinueOnError)
+	status := fs.String("status", "", "filter by order status, for example NEW")
+	search := fs.String("search", "", "search by order ID, AWB or customer")
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "orders per page")
+	from := fs.String("from", "", "created from date, YYYY-MM-DD")
+	to := fs.String("to", "", "created to date, YYYY-MM-DD")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("orders list: unexpected arguments %v", positional)
+	}
+
+	params := &orders.OrdersListParams{
+		Page:    *page,
+		PerPage: *perPage,
+		Search:  *search,
+		From:    *from,
+		To:      *to,
+	}
+	if *status != "" {
+		params.FilterBy = orders.OrderFilterByStatus
+		params.Filter = *status
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Orders.GetOrdersWithParams(ctx, params)
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, ordersTable(response))
+}
+
+func ordersTable(response *orders.OrdersListResponse) table {
+	view := table{headers: []string{"ID", "CHANNEL ORDER ID", "CUSTOMER", "STATUS", "PAYMENT", "TOTAL", "CREATED"}}
+	for _, order := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(order.ID, 10),
+			order.ChannelOrderID,
+			order.CustomerName,
+			order.Status,
+			string(order.PaymentMethod),
+			order.Total.String(),
+			order.CreatedAt,
+		})
+	}
+	return view
+}
+
+func runNDRAct(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("ndr act", flag.ContinueOnError)
+	action := fs.String("action", "", "re-attempt, return or fake-attempt")
+	comments := fs.String("comments", "", "comments for the courier")
+	phone := fs.String("phone", "", "updated customer phone")
+	address1 := fs.String("address1", "", "updated address line 1")
+	address2 := fs.String("address2", "", "updated address line 2")
+	deferredDate := fs.String("deferred-date", "", "re-attempt date, YYYY-MM-DD")
+	remarks := fs.String("remarks", "", "remarks")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("ndr act: expected exactly one AWB")
+	}
+	switch ndr.Action(*action) {
+	case ndr.ActionReattempt, ndr.ActionReturn, ndr.ActionFakeAttempt:
+	default:
+		return usagef("ndr act: --action must be one of re-attempt, return, fake-attempt")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.NDR.Act(ctx, &ndr.ActionRequest{
+		AWB:          positional[0],
+		Action:       ndr.Action(*action),
+		Comments:     *comments,
+		Phone:        *phone,
+		Address1:     *address1,
+		Address2:     *address2,
+		DeferredDate: *deferredDate,
+		Remarks:      *remarks,
+	})
+	if err != nil {
+		return err
+	}
+
+	view := table{
+		headers: []string{"AWB", "ACTION", "STATUS"},
+		rows:    [][]string{{positional[0], *action, response.Status}},
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runLabelsDownload(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("labels download", flag.ContinueOnError)
+	shipments := fs.String("shipment", "", "comma separated shipment IDs")
+	out := fs.String("o", "labels.pdf", "output PDF path, or - for stdout")
+	kinds := fs.String("kinds", string(documents.KindLabel), "comma separated documents per shipment: label, invoice, label_invoice")
+	manifest := fs.String("manifest", "", "include the manifest: first or last")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("labels download: unexpected arguments %v", positional)
+	}
+
+	request := &documents.Request{Manifest: documents.ManifestPosition(*manifest)}
+	switch request.Manifest {
+	case documents.ManifestNone, documents.ManifestFirst, documents.ManifestLast:
+	default:
+		return usagef("labels download: --manifest must be first or last")
+	}
+	if request.ShipmentIDs, err = parseShipmentIDs("labels download", *shipments); err != nil {
+		return err
+	}
+	if len(request.ShipmentIDs) == 0 {
+		return usagef("labels download: --shipment is required")
+	}
+	for _, value := range splitList(*kinds) {
+		kind := documents.Kind(value)
+		switch kind {
+		case documents.KindLabel, documents.KindInvoice, documents.KindLabelInvoice:
+		default:
+			return usagef("labels download: unsupported document kind %q", value)
+		}
+		request.Kinds = append(request.Kinds, kind)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+
+	report := env.stdout
+	var result *documents.Result
+	if *out == "-" {
+		report = env.stderr
+		result, err = client.Documents.Print(ctx, request, env.stdout)
+	} else {
+		result, err = printToFile(ctx, client, request, *out)
+	}
+	if err != nil && result == nil {
+		return err
+	}
+
+	view := table{headers: []string{"SHIPMENT", "KIND", "PAGES", "STATUS"}}
+	for _, document := range result.Documents {
+		view.rows = append(view.rows, []string{strconv.FormatInt(document.ShipmentID, 10), string(document.Kind), strconv.Itoa(document.Pages), "ok"})
+	}
+	for _, missing := range result.NotCreated {
+		view.rows = append(view.rows, []string{strconv.FormatInt(missing.ShipmentID, 10), string(missing.Kind), "0", "not created: " + missing.Reason})
+	}
+	if renderErr := render(report, opts.output, result, view); renderErr != nil && err == nil {
+		err = renderErr
+	}
+	return err
+}
+
+// printToFile writes the merged PDF next to path and renames it into place,
+// so a failed run never leaves a truncated file behind. CreateTemp makes the
+// file owner-only, so it is opened up to 0644 like any other saved PDF.
+func printToFile(ctx context.Context, client *shiprocket.Client, request *documents.Request, path string) (*documents.Result, error) {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+
+	result, err := client.Documents.Print(ctx, request, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0o644)
+	}
+	if err != nil {
+		return result, err
+	}
+	return result, os.Rename(file.Name(), path)
+}
+
+func runWalletBalance(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("wallet balance", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("wallet balance: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Account.GetWalletBalance(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{
+		headers: []string{"BALANCE"},
+		rows:    [][]string{{response.Data.BalanceAmount.String()}},
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+// parseShipmentIDs parses a comma separated list of shipment IDs.
+func parseShipmentIDs(command, value string) ([]int64, error) {
+	var ids []int64
+	for _, item := range splitList(value) {
+		id, err := strconv.ParseInt(item, 10, 64)
+		if err != nil {
+			return nil, usagef("%s: invalid shipment ID %q", command, item)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	shiprocket "github.com/Niyantra-Labs/shiprocket-gosdk"
+)
+
+const defaultProfileName = "default"
+
+var errNoCredentials = errors.New("no credentials: set SHIPROCKET_TOKEN, or SHIPROCKET_EMAIL and SHIPROCKET_PASSWORD, or configure a profile")
+
+type profile struct {
+	BaseURL  string `json:"base_url,omitempty"`
+	Token    string `json:"token,omitempty"`
+	Email    string `json:"email,omitempty"`
+	Password string `json:"password,omitempty"`
+}
+
+type configFile struct {
+	DefaultProfile string             `json:"default_profile,omitempty"`
+	Profiles       map[string]profile `json:"profiles"`
+}
+
+func defaultConfigPath(getenv func(string) string) string {
+	if path := getenv("SHIPROCKET_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "shiprocket", "config.json")
+}
+
+// resolveProfile loads the selected profile from the config file and lets
+// environment variables override individual fields. A missing config file is
+// only an error when the caller asked for a specific file or profile.
+func resolveProfile(opts *globalOptions, getenv func(string) string) (profile, error) {
+	path := opts.config
+	explicitPath := path != ""
+	if !explicitPath {
+		path = defaultConfigPath(getenv)
+	}
+
+	name := opts.profile
+	if name == "" {
+		name = getenv("SHIPROCKET_PROFILE")
+	}
+	explicitProfile := name != ""
+
+	var selected profile
+	if path != "" {
+		data, err := os.ReadFile(path)
+		switch {
+		case err == nil:
+			var file configFile
+			if err := json.Unmarshal(data, &file); err != nil {
+				return profile{}, fmt.Errorf("read config %s: %w", path, err)
+			}
+			if name == "" {
+				name = file.DefaultProfile
+			}
+			if name == "" {
+				name = defaultProfileName
+			}
+			found, ok := file.Profiles[name]
+			if !ok && explicitProfile {
+				return profile{}, fmt.Errorf("profile %q not found in %s", name, path)
+			}
+			selected = found
+		case errors.Is(err, os.ErrNotExist) && !explicitPath && !explicitProfile:
+		default:
+			return profile{}, fmt.Errorf("read config: %w", err)
+		}
+	}
+
+	if value := getenv("SHIPROCKET_BASE_URL"); value != "" {
+		selected.BaseURL = value
+	}
+	if value := getenv("SHIPROCKET_TOKEN"); value != "" {
+		selected.Token = value
+	}
+	if value := getenv("SHIPROCKET_EMAIL"); value != "" {
+		selected.Email = value
+	}
+	if value := getenv("SHIPROCKET_PASSWORD"); value != "" {
+		selected.Password = value
+	}
+
+	return selected, nil
+}
+
+func newClient(selected profile) (*shiprocket.Client, error) {
+	cfg := shiprocket.Config{
+		BaseURL:   selected.BaseURL,
+		Token:     selected.Token,
+		UserAgent: "shiprocket-cli",
+	}
+	if cfg.Token == "" {
+		if selected.Email == "" || selected.Password == "" {
+			return nil, errNoCredentials
+		}
+		cfg.Credentials = &shiprocket.Credentials{Email: selected.Email, Password: selected.Password}
+	}
+	return shiprocket.NewClient(cfg), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+	"strings"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/location"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/orders"
+)
+
+func runLocationCountries(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("location countries", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("location countries: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Location.ListCountries(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "NAME", "ISO", "ISD"}}
+	for _, country := range response.Data {
+		view.rows = append(view.rows, []string{strconv.FormatInt(country.ID, 10), country.Name, country.ISOCode2, country.ISDCode})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runLocationPostcode(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("location postcode", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("location postcode: expected exactly one postcode")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Location.GetPostcodeDetails(ctx, &location.PostcodeDetailsRequest{Postcode: positional[0]})
+	if err != nil {
+		return err
+	}
+
+	details := response.PostcodeDetails
+	view := table{
+		headers: []string{"POSTCODE", "CITY", "STATE", "LOCALITIES"},
+		rows:    [][]string{{details.Postcode, details.City, details.State, strings.Join(details.Locality, "; ")}},
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runHyperlocalServiceability(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("hyperlocal serviceability", flag.ContinueOnError)
+	pickup := fs.String("pickup", "", "pickup pincode")
+	delivery := fs.String("delivery", "", "delivery pincode")
+	weight := fs.String("weight", "", "weight in kg")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("hyperlocal serviceability: unexpected arguments %v", positional)
+	}
+	if *pickup == "" || *delivery == "" || *weight == "" {
+		return usagef("hyperlocal serviceability: --pickup, --delivery and --weight are required")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Hyperlocal.CheckServiceability(ctx, &courier.ServiceabilityParams{
+		PickupPostcode:   *pickup,
+		DeliveryPostcode: *delivery,
+		Weight:           *weight,
+	})
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, courierTable(response))
+}
+
+func runHyperlocalOrders(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("hyperlocal orders", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "orders per page")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("hyperlocal orders: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Hyperlocal.ListOrders(ctx, &orders.OrdersListParams{Page: *page, PerPage: *perPage})
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, ordersTable(response))
+}
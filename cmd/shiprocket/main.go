@@ -0,0 +1,65 @@
+// Command shiprocket runs common Shiprocket operations from the shell.
+//
+// Usage:
+//
+//	shiprocket [global flags] <command> <subcommand> [flags] [args]
+//
+// Commands:
+//
+//	track awb <awb>                         track a shipment by AWB
+//	track shipment <shipment-id>            track a shipment by ID
+//	shipments list                          list shipments
+//	shipments get <shipment-id>             show a shipment
+//	shipments cancel <awb>...               cancel shipments
+//	shipments manifest --shipment 1,2       generate a manifest
+//	orders list [--status NEW]              list orders
+//	ndr act <awb> --action re-attempt       act on an NDR shipment
+//	labels download --shipment 1,2 -o f.pdf download merged labels
+//	pickup list                             list pickup locations
+//	pickup generate --shipment 1,2          request a pickup
+//	couriers serviceability --pickup ...    list couriers for a lane
+//	couriers assign <shipment-id>           assign an AWB
+//	returns list                            list return orders
+//	products list                           list products
+//	channels list                           list sales channels
+//	listings list                           list channel listings
+//	inventory list                          list inventory
+//	international serviceability ...        list international couriers
+//	international track                     track international orders
+//	hyperlocal serviceability --pickup ...  list hyperlocal couriers
+//	hyperlocal orders                       list hyperlocal orders
+//	location countries                      list countries
+//	location postcode <postcode>            show postcode details
+//	wallet balance                          show the wallet balance
+//	account statement [--from --to]         show the account statement
+//	account discrepancy                     list weight discrepancies
+//	auth login                              print a token for the credentials
+//	auth logout                             invalidate the current token
+//
+// Global flags, accepted anywhere on the command line:
+//
+//	--output json|table|csv  output format (default table)
+//	--profile name           profile from the config file
+//	--config path            config file path
+//
+// Credentials are read from SHIPROCKET_TOKEN, or SHIPROCKET_EMAIL and
+// SHIPROCKET_PASSWORD, and fall back to the selected profile in the config
+// file. See docs/cli.md for the file format and exit codes.
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], &environment{
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+		getenv: os.Getenv,
+	})
+	stop()
+	os.Exit(code)
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newTestEnv(values map[string]string) (*environment, *bytes.Buffer, *bytes.Buffer) {
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	return &environment{
+		stdout: stdout,
+		stderr: stderr,
+		getenv: func(key string) string { return values[key] },
+	}, stdout, stderr
+}
+
+func onePagePDF() []byte {
+	return []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 288 432] >>\nendobj\ntrailer\n<< /Size 4 /Root 1 0 R >>\n%%EOF\n")
+}
+
+func TestCommandsRenderResponses(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/files/label.pdf" && r.URL.Path != "/v1/external/auth/login" && r.Header.Get("Authorization") != "Bearer env-token" {
+			t.Fatalf("unexpected authorization: %q", r.Header.Get("Authorization"))
+		}
+		switch r.URL.Path {
+		case "/v1/external/courier/track/awb/141123221084922":
+			_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1,"shipment_status":7,"shipment_track":[],"shipment_track_activities":[{"date":"2021-07-05 15:33:00","status":"DLVD","activity":"Delivered","location":"Delhi","sr-status":"7","sr-status-label":"DELIVERED"}]}}`))
+		case "/v1/external/orders":
+			if r.URL.Query().Get("page") == "3" {
+				_, _ = w.Write([]byte(`{"data":[{"id":16167172,"channel_order_id":"HL-1","customer_name":"Kakashi","status":"NEW","payment_method":"prepaid","total":"250.00","created_at":"31 Jul 2019"}]}`))
+				return
+			}
+			if got := r.URL.Query().Encode(); got != "filter=NEW&filter_by=status&per_page=2" {
+				t.Fatalf("unexpected query: %s", got)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":16167171,"channel_order_id":"224-447","customer_name":"Naruto Uzumaki","status":"NEW","payment_method":"cod","total":"9000.00","created_at":"31 Jul 2019, 12:37 PM"}],"meta":{"pagination":{"total":1}}}`))
+		case "/v1/external/ndr/19041211125783/action":
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]any
+			_ = json.Unmarshal(body, &payload)
+			if payload["action"] != "re-attempt" || payload["comments"] != "customer available" {
+				t.Fatalf("unexpected ndr payload: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"status":"success"}`))
+		case "/v1/external/account/details/wallet-balance":
+			_, _ = w.Write([]byte(`{"data":{"balance_amount":"1520.50"}}`))
+		case "/v1/external/courier/generate/label":
+			_, _ = fmt.Fprintf(w, `{"label_created":1,"label_url":"%s/files/label.pdf","response":"ok","not_created":[]}`, server.URL)
+		case "/files/label.pdf":
+			_, _ = w.Write(onePagePDF())
+		case "/v1/external/settings/company/pickup":
+			_, _ = w.Write([]byte(`{"data":{"shipping_address":[{"id":4512,"pickup_location":"Home","city":"Delhi","state":"Delhi","pin_code":"110001","is_primary_location":1}]}}`))
+		case "/v1/external/courier/generate/pickup":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"shipment_id":[16016920,16016921]}` {
+				t.Fatalf("unexpected pickup payload: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"pickup_status":1,"response":{"pickup_scheduled_date":"2021-12-10 12:39:48","pickup_token_number":"Reference No: 194","data":"Pickup is confirmed"}}`))
+		case "/v1/external/courier/serviceability/":
+			if r.URL.Query().Get("is_new_hyperlocal") == "1" {
+				_, _ = w.Write([]byte(`{"data":{"available_courier_companies":[{"courier_company_id":172,"courier_name":"Dunzo","rate":45,"etd":"Dec 10, 2021","estimated_delivery_days":"0"}]}}`))
+				return
+			}
+			if got := r.URL.Query().Get("cod"); got != "1" {
+				t.Fatalf("unexpected cod flag: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"data":{"available_courier_companies":[{"courier_company_id":10,"courier_name":"Delhivery","rate":92.5,"etd":"Dec 14, 2021","estimated_delivery_days":"3"}]}}`))
+		case "/v1/external/courier/assign/awb":
+			_, _ = w.Write([]byte(`{"awb_assign_status":1,"response":{"data":{"awb_code":"19041211125783","courier_name":"Delhivery"}}}`))
+		case "/v1/external/orders/processing/return":
+			_, _ = w.Write([]byte(`{"data":[{"id":27215,"channel_order_id":"R-1","customer_name":"Sakura","status":"RETURN PENDING","total":"500.00","created_at":"12 Dec 2021"}]}`))
+		case "/v1/external/products":
+			_, _ = w.Write([]byte(`{"data":[{"id":9,"sku":"TSHIRT-M","name":"T-Shirt","category_name":"Apparel","mrp":"499","weight":"0.3"}]}`))
+		case "/v1/external/channels":
+			_, _ = w.Write([]byte(`{"data":[{"id":1337,"name":"Custom","status":"Active","orders_synced_on":"2021-12-01"}]}`))
+		case "/v1/external/listings":
+			_, _ = w.Write([]byte(`{"data":[{"id":5,"title":"T-Shirt","sku":"TSHIRT-M","channel_sku":"SHOP-TSHIRT-M","channel_name":"Shopify","inventory":7}]}`))
+		case "/v1/external/inventory":
+			_, _ = w.Write([]byte(`{"data":[{"id":9,"sku":"TSHIRT-M","name":"T-Shirt","total_quantity":10,"available_quantity":7,"blocked_quantity":3}]}`))
+		case "/v1/external/international/courier/serviceability":
+			if got := r.URL.Query().Get("delivery_country"); got != "US" {
+				t.Fatalf("unexpected country: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"status":200,"data":{"available_courier_companies":[{"courier_company_id":66,"courier_name":"Aramex International","etd":"Dec 20, 2021","estimated_delivery_days":"7","rate":{"rate":1450}}]}}`))
+		case "/v1/external/international/orders/track":
+			_, _ = w.Write([]byte(`{"data":[{"id":31,"channel_order_id":"INT-1","customer_name":"Hinata","customer_country":"United States","status":"SHIPPED","created_at":"10 Dec 2021"}]}`))
+		case "/v1/external/account/details/statement":
+			_, _ = w.Write([]byte(`{"data":[{"transaction_id":"TXN1","awb_code":"19041211125783","description":"Freight charges","debit_amount":"92.50","credit_amount":"0","balance_amount":"1428.00","created_at":"2021-12-10"}]}`))
+		case "/v1/external/billing/discrepancy":
+			_, _ = w.Write([]byte(`{"status":200,"data":[{"awb_code":"19041211125783","charged_weight":1.5}]}`))
+		case "/v1/external/courier/track/shipment/16016920":
+			_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1,"shipment_track":[{"awb_code":"19041211125783","courier_name":"Delhivery","current_status":"PICKED UP","origin":"Delhi","destination":"Mumbai"}],"shipment_track_activities":[]}}`))
+		case "/v1/external/shipments":
+			_, _ = w.Write([]byte(`{"data":[{"id":16016920,"order_id":16167171,"awb":"19041211125783","status":"PICKED UP","created_at":"10 Dec 2021","channel_name":"Custom","payment_method":"cod"}]}`))
+		case "/v1/external/shipments/16016920":
+			_, _ = w.Write([]byte(`{"data":{"id":16016920,"order_id":16167171,"awb":"19041211125783","courier":"Delhivery","weight":"0.5","dimensions":"10x10x10","total":"9000.00","shipping_address":{"city":"Mumbai","pincode":"400001"}}}`))
+		case "/v1/external/orders/cancel/shipment/awbs":
+			body, _ := io.ReadAll(r.Body)
+			if string(body) != `{"awbs":["19041211125783"]}` {
+				t.Fatalf("unexpected cancel payload: %s", body)
+			}
+			_, _ = w.Write([]byte(`{"message":"Bulk Shipment cancellation is in progress."}`))
+		case "/v1/external/manifests/generate":
+			_, _ = w.Write([]byte(`{"status":1,"manifest_url":"https://example.com/manifest.pdf"}`))
+		case "/v1/external/countries":
+			_, _ = w.Write([]byte(`{"data":[{"id":99,"name":"India","iso_code_2":"IN","isd_code":"+91"}]}`))
+		case "/v1/external/open/postcode/details":
+			if got := r.URL.Query().Get("postcode"); got != "110001" {
+				t.Fatalf("unexpected postcode: %q", got)
+			}
+			_, _ = w.Write([]byte(`{"success":true,"postcode_details":{"postcode":"110001","city":"New Delhi","state":"Delhi","locality":["Connaught Place","Janpath"]}}`))
+		case "/v1/external/auth/login":
+			if got := r.Header.Get("Authorization"); got != "" {
+				t.Fatalf("login sent authorization %q", got)
+			}
+			_, _ = w.Write([]byte(`{"token":"fresh-token"}`))
+		case "/v1/external/auth/logout":
+			_, _ = w.Write([]byte(`{}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"SHIPROCKET_BASE_URL": server.URL,
+		"SHIPROCKET_TOKEN":    "env-token",
+		"SHIPROCKET_EMAIL":    "ops@example.com",
+		"SHIPROCKET_PASSWORD": "secret",
+		"SHIPROCKET_CONFIG":   filepath.Join(t.TempDir(), "missing.json"),
+	}
+	output := filepath.Join(t.TempDir(), "labels.pdf")
+
+	tests := []struct {
+		name   string
+		args   []string
+		expect string
+	}{
+		{name: "track awb table", args: []string{"track", "awb", "141123221084922"}, expect: "DELIVERED"},
+		{name: "orders list csv", args: []string{"--output", "csv", "orders", "list", "--status", "NEW", "--per-page", "2"}, expect: "ID,CHANNEL ORDER ID,CUSTOMER,STATUS,PAYMENT,TOTAL,CREATED\n16167171,224-447,Naruto Uzumaki,NEW,cod,9000.00,\"31 Jul 2019, 12:37 PM\"\n"},
+		{name: "ndr act with trailing flags", args: []string{"ndr", "act", "19041211125783", "--action", "re-attempt", "--comments", "customer available"}, expect: "success"},
+		{name: "wallet balance json", args: []string{"wallet", "balance", "--output", "json"}, expect: `"balance_amount": "1520.50"`},
+		{name: "labels download", args: []string{"labels", "download", "--shipment", "16016920", "-o", output}, expect: "16016920  label  1      ok"},
+		{name: "pickup list", args: []string{"pickup", "list"}, expect: "4512  Home      Delhi  Delhi  110001   true"},
+		{name: "pickup generate", args: []string{"pickup", "generate", "--shipment", "16016920,16016921"}, expect: "Reference No: 194"},
+		{name: "couriers serviceability", args: []string{"--output", "csv", "couriers", "serviceability", "--pickup", "110001", "--delivery", "400001", "--weight", "0.5", "--cod"}, expect: "10,Delhivery,92.50,\"Dec 14, 2021\",3\n"},
+		{name: "couriers assign", args: []string{"couriers", "assign", "16016920", "--courier", "10"}, expect: "19041211125783"},
+		{name: "returns list", args: []string{"returns", "list"}, expect: "RETURN PENDING"},
+		{name: "products list", args: []string{"--output", "csv", "products", "list"}, expect: "9,TSHIRT-M,T-Shirt,Apparel,499,0.3\n"},
+		{name: "channels list", args: []string{"channels", "list"}, expect: "Custom"},
+		{name: "listings list", args: []string{"--output", "csv", "listings", "list"}, expect: "5,TSHIRT-M,SHOP-TSHIRT-M,Shopify,T-Shirt,7\n"},
+		{name: "inventory list", args: []string{"--output", "csv", "inventory", "list"}, expect: "9,TSHIRT-M,T-Shirt,10,7,3\n"},
+		{name: "international serviceability", args: []string{"--output", "csv", "international", "serviceability", "--country", "US", "--weight", "0.5"}, expect: "66,Aramex International,1450.00"},
+		{name: "international track", args: []string{"international", "track"}, expect: "United States"},
+		{name: "account statement", args: []string{"--output", "csv", "account", "statement"}, expect: "TXN1,19041211125783,Freight charges,92.50,0,1428.00,2021-12-10\n"},
+		{name: "track shipment", args: []string{"track", "shipment", "16016920"}, expect: "PICKED UP"},
+		{name: "shipments list", args: []string{"--output", "csv", "shipments", "list"}, expect: "16016920,16167171,19041211125783,PICKED UP,Custom,cod,10 Dec 2021\n"},
+		{name: "shipments get", args: []string{"--output", "csv", "shipments", "get", "16016920"}, expect: "16016920,16167171,19041211125783,Delhivery,0.5,10x10x10,9000.00,Mumbai,400001\n"},
+		{name: "shipments cancel", args: []string{"shipments", "cancel", "19041211125783"}, expect: "cancellation is in progress"},
+		{name: "shipments manifest", args: []string{"shipments", "manifest", "--shipment", "16016920"}, expect: "https://example.com/manifest.pdf"},
+		{name: "hyperlocal serviceability", args: []string{"--output", "csv", "hyperlocal", "serviceability", "--pickup", "110001", "--delivery", "110002", "--weight", "0.5"}, expect: "172,Dunzo,45.00"},
+		{name: "hyperlocal orders", args: []string{"hyperlocal", "orders", "--page", "3"}, expect: "Kakashi"},
+		{name: "location countries", args: []string{"--output", "csv", "location", "countries"}, expect: "99,India,IN,+91\n"},
+		{name: "location postcode", args: []string{"--output", "csv", "location", "postcode", "110001"}, expect: "110001,New Delhi,Delhi,Connaught Place; Janpath\n"},
+		{name: "auth login", args: []string{"auth", "login"}, expect: "fresh-token"},
+		{name: "auth logout", args: []string{"auth", "logout"}, expect: "logged out"},
+		{name: "account discrepancy", args: []string{"--output", "csv", "account", "discrepancy"}, expect: "awb_code,charged_weight\n19041211125783,1.5\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEnv, stdout, stderr := newTestEnv(env)
+			if code := run(context.Background(), tt.args, testEnv); code != exitOK {
+				t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+			}
+			if !strings.Contains(stdout.String(), tt.expect) {
+				t.Fatalf("unexpected output:\n%s", stdout.String())
+			}
+		})
+	}
+
+	written, err := os.ReadFile(output)
+	if err != nil || !bytes.HasPrefix(written, []byte("%PDF-")) {
+		t.Fatalf("unexpected labels file: %v", err)
+	}
+	if info, err := os.Stat(output); err != nil || info.Mode().Perm() != 0o644 {
+		t.Fatalf("expected labels file mode 0644, got %v: %v", info.Mode().Perm(), err)
+	}
+}
+
+func TestParseArgsStopsAtTerminator(t *testing.T) {
+	fs := flag.NewFlagSet("ndr act", flag.ContinueOnError)
+	action := fs.String("action", "", "")
+	positional, err := parseArgs(fs, &globalOptions{output: formatTable}, []string{"AWB1", "--action", "return", "--", "--output", "-AWB2"})
+	if err != nil {
+		t.Fatalf("parseArgs: %v", err)
+	}
+	if *action != "return" || strings.Join(positional, " ") != "AWB1 --output -AWB2" {
+		t.Fatalf("unexpected parse: action %q, positional %q", *action, positional)
+	}
+}
+
+func TestExitCodesFollowSDKErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/account/details/wallet-balance":
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"message":"Token has expired","status_code":401}`))
+		case "/v1/external/courier/track/awb/missing":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"AWB not found","status_code":404}`))
+		case "/v1/external/orders":
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	env := map[string]string{
+		"SHIPROCKET_BASE_URL": server.URL,
+		"SHIPROCKET_TOKEN":    "env-token",
+		"SHIPROCKET_CONFIG":   filepath.Join(t.TempDir(), "missing.json"),
+	}
+
+	tests := []struct {
+		name string
+		env  map[string]string
+		args []string
+		want int
+	}{
+		{name: "auth", env: env, args: []string{"wallet", "balance"}, want: exitAuth},
+		{name: "business", env: env, args: []string{"track", "awb", "missing"}, want: exitBusiness},
+		{name: "rate limited", env: env, args: []string{"orders", "list"}, want: exitRateLimited},
+		{name: "server", env: env, args: []string{"ndr", "act", "AWB1", "--action", "return"}, want: exitServer},
+		{name: "usage", env: env, args: []string{"ndr", "act", "AWB1", "--action", "retry"}, want: exitUsage},
+		{name: "unknown command", env: env, args: []string{"manifests", "list"}, want: exitUsage},
+		{name: "bad output format", env: env, args: []string{"wallet", "balance", "--output", "xml"}, want: exitUsage},
+		{name: "no credentials", env: map[string]string{"SHIPROCKET_CONFIG": env["SHIPROCKET_CONFIG"]}, args: []string{"wallet", "balance"}, want: exitAuth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testEnv, _, stderr := newTestEnv(tt.env)
+			if code := run(context.Background(), tt.args, testEnv); code != tt.want {
+				t.Fatalf("expected exit code %d, got %d: %s", tt.want, code, stderr.String())
+			}
+		})
+	}
+}
+
+func TestProfilesAreReadFromConfigFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer staging-token" {
+			t.Fatalf("unexpected authorization: %q", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"data":{"balance_amount":"10.00"}}`))
+	}))
+	defer server.Close()
+
+	config := filepath.Join(t.TempDir(), "config.json")
+	contents := fmt.Sprintf(`{"default_profile":"prod","profiles":{"prod":{"token":"prod-token","base_url":"http://127.0.0.1:1"},"staging":{"token":"staging-token","base_url":"%s"}}}`, server.URL)
+	if err := os.WriteFile(config, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+
+	testEnv, stdout, stderr := newTestEnv(map[string]string{})
+	if code := run(context.Background(), []string{"--config", config, "--profile", "staging", "wallet", "balance"}, testEnv); code != exitOK {
+		t.Fatalf("unexpected exit code %d: %s", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "10.00") {
+		t.Fatalf("unexpected output: %s", stdout.String())
+	}
+
+	testEnv, _, _ = newTestEnv(map[string]string{})
+	if code := run(context.Background(), []string{"--config", config, "--profile", "missing", "wallet", "balance"}, testEnv); code != exitError {
+		t.Fatalf("expected missing profile to fail with %d, got %d", exitError, code)
+	}
+}
@@ -0,0 +1,171 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+)
+
+func runTrackShipment(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("track shipment", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("track shipment: expected exactly one shipment ID")
+	}
+	shipmentID, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return usagef("track shipment: invalid shipment ID %q", positional[0])
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.TrackByShipmentID(ctx, &shipment.TrackByShipmentIDRequest{ShipmentID: shipmentID})
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, trackingTable(response))
+}
+
+func runShipmentsList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("shipments list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("shipments list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.List(ctx, &shipment.ListParams{Page: *page})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "ORDER ID", "AWB", "STATUS", "CHANNEL", "PAYMENT", "CREATED"}}
+	for _, summary := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(summary.ID, 10),
+			strconv.FormatInt(summary.OrderID, 10),
+			summary.AWB,
+			summary.Status,
+			summary.ChannelName,
+			summary.PaymentMethod,
+			summary.CreatedAt,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runShipmentsGet(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("shipments get", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("shipments get: expected exactly one shipment ID")
+	}
+	shipmentID, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return usagef("shipments get: invalid shipment ID %q", positional[0])
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.Get(ctx, &shipment.GetRequest{ShipmentID: shipmentID})
+	if err != nil {
+		return err
+	}
+
+	detail := response.Data
+	view := table{
+		headers: []string{"ID", "ORDER ID", "AWB", "COURIER", "WEIGHT", "DIMENSIONS", "TOTAL", "CITY", "PINCODE"},
+		rows: [][]string{{
+			strconv.FormatInt(detail.ID, 10),
+			strconv.FormatInt(detail.OrderID, 10),
+			stringValue(detail.AWB),
+			stringValue(detail.Courier),
+			detail.Weight.String(),
+			detail.Dimensions,
+			detail.Total.String(),
+			detail.ShippingAddress.City,
+			detail.ShippingAddress.Pincode,
+		}},
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runShipmentsCancel(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("shipments cancel", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) == 0 {
+		return usagef("shipments cancel: expected at least one AWB")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.CancelByAWB(ctx, &shipment.CancelShipmentsRequest{AWBs: positional})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"MESSAGE"}, rows: [][]string{{response.Message}}}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runShipmentsManifest(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("shipments manifest", flag.ContinueOnError)
+	shipments := fs.String("shipment", "", "comma separated shipment IDs")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("shipments manifest: unexpected arguments %v", positional)
+	}
+	ids, err := parseShipmentIDs("shipments manifest", *shipments)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return usagef("shipments manifest: --shipment is required")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Shipments.GenerateManifest(ctx, &shipment.GenerateManifestRequest{ShipmentID: ids})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"MANIFEST URL"}, rows: [][]string{{response.ManifestURL}}}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
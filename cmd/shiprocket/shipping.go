@@ -0,0 +1,297 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"strconv"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/international"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/returns"
+)
+
+func runPickupList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("pickup list", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("pickup list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.PickupAddresses.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "LOCATION", "CITY", "STATE", "PINCODE", "PRIMARY"}}
+	for _, address := range response.Data.ShippingAddresses {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(address.ID, 10),
+			address.PickupLocation,
+			address.City,
+			address.State,
+			address.PinCode,
+			strconv.FormatBool(address.IsPrimaryLocation.Bool()),
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runPickupGenerate(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("pickup generate", flag.ContinueOnError)
+	shipments := fs.String("shipment", "", "comma separated shipment IDs")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("pickup generate: unexpected arguments %v", positional)
+	}
+	ids, err := parseShipmentIDs("pickup generate", *shipments)
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		return usagef("pickup generate: --shipment is required")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Couriers.GeneratePickup(ctx, &courier.GeneratePickupRequest{ShipmentID: ids})
+	if err != nil {
+		return err
+	}
+
+	scheduled, token, message := response.PickupScheduledDate, response.PickupTokenNumber, response.Message
+	if response.Response != nil {
+		scheduled, token = response.Response.PickupScheduledDate, response.Response.PickupTokenNumber
+		if message == "" {
+			message = response.Response.Data
+		}
+	}
+	view := table{
+		headers: []string{"SCHEDULED", "TOKEN", "MESSAGE"},
+		rows:    [][]string{{scheduled, token, message}},
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runCouriersServiceability(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("couriers serviceability", flag.ContinueOnError)
+	pickup := fs.String("pickup", "", "pickup pincode")
+	delivery := fs.String("delivery", "", "delivery pincode")
+	weight := fs.String("weight", "", "weight in kg")
+	cod := fs.Bool("cod", false, "cash on delivery")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("couriers serviceability: unexpected arguments %v", positional)
+	}
+	if *pickup == "" || *delivery == "" || *weight == "" {
+		return usagef("couriers serviceability: --pickup, --delivery and --weight are required")
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Couriers.CheckServiceability(ctx, &courier.ServiceabilityParams{
+		PickupPostcode:   *pickup,
+		DeliveryPostcode: *delivery,
+		Weight:           *weight,
+		COD:              cod,
+	})
+	if err != nil {
+		return err
+	}
+	return render(env.stdout, opts.output, response, courierTable(response))
+}
+
+func courierTable(response *courier.ServiceabilityResponse) table {
+	view := table{headers: []string{"COURIER ID", "COURIER", "RATE", "ETD", "DAYS"}}
+	for _, company := range response.Data.AvailableCourierCompanies {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(company.CourierCompanyID, 10),
+			company.CourierName,
+			formatAmount(company.Rate.Float64()),
+			company.ETD,
+			company.EstimatedDeliveryDays.String(),
+		})
+	}
+	return view
+}
+
+func runCouriersAssign(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("couriers assign", flag.ContinueOnError)
+	courierID := fs.Int64("courier", 0, "courier company ID, or 0 to let Shiprocket pick one")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 1 {
+		return usagef("couriers assign: expected exactly one shipment ID")
+	}
+	shipmentID, err := strconv.ParseInt(positional[0], 10, 64)
+	if err != nil {
+		return usagef("couriers assign: invalid shipment ID %q", positional[0])
+	}
+
+	request := &courier.AssignAWBRequest{ShipmentID: shipmentID}
+	if *courierID != 0 {
+		request.CourierID = courierID
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Couriers.AssignAWB(ctx, request)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"SHIPMENT", "AWB", "COURIER"}}
+	if response.Response != nil {
+		view.rows = append(view.rows, []string{positional[0], response.Response.Data.AWBCode, response.Response.Data.CourierName})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runReturnsList(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("returns list", flag.ContinueOnError)
+	page := fs.Int("page", 0, "page number")
+	perPage := fs.Int("per-page", 0, "returns per page")
+	from := fs.String("from", "", "created from date, YYYY-MM-DD")
+	to := fs.String("to", "", "created to date, YYYY-MM-DD")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("returns list: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.Returns.ListReturnOrders(ctx, &returns.ListReturnOrdersParams{
+		Page:    *page,
+		PerPage: *perPage,
+		From:    *from,
+		To:      *to,
+	})
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "CHANNEL ORDER ID", "CUSTOMER", "STATUS", "TOTAL", "CREATED"}}
+	for _, order := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(order.ID, 10),
+			order.ChannelOrderID,
+			order.CustomerName,
+			order.Status,
+			order.Total.String(),
+			order.CreatedAt,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runInternationalServiceability(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("international serviceability", flag.ContinueOnError)
+	pickup := fs.String("pickup", "", "pickup pincode")
+	country := fs.String("country", "", "delivery country code, for example US")
+	weight := fs.String("weight", "", "weight in kg")
+	cod := fs.Bool("cod", false, "cash on delivery")
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("international serviceability: unexpected arguments %v", positional)
+	}
+	if *country == "" || *weight == "" {
+		return usagef("international serviceability: --country and --weight are required")
+	}
+
+	params := &international.ServiceabilityParams{
+		PickupPostcode:  *pickup,
+		DeliveryCountry: *country,
+		Weight:          *weight,
+	}
+	if *cod {
+		params.COD = 1
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.International.CheckServiceability(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"COURIER ID", "COURIER", "RATE", "ETD", "DAYS"}}
+	for _, company := range response.Data.AvailableCourierCompanies {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(company.CourierCompanyID, 10),
+			company.CourierName,
+			formatAmount(company.Rate.Rate.Float64()),
+			company.ETD,
+			company.EstimatedDeliveryDays,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+func runInternationalTrack(ctx context.Context, env *environment, opts *globalOptions, args []string) error {
+	fs := flag.NewFlagSet("international track", flag.ContinueOnError)
+	positional, err := parseArgs(fs, opts, args)
+	if err != nil {
+		return err
+	}
+	if len(positional) != 0 {
+		return usagef("international track: unexpected arguments %v", positional)
+	}
+
+	client, err := clientFor(env, opts)
+	if err != nil {
+		return err
+	}
+	response, err := client.International.TrackOrders(ctx)
+	if err != nil {
+		return err
+	}
+
+	view := table{headers: []string{"ID", "CHANNEL ORDER ID", "CUSTOMER", "COUNTRY", "STATUS", "CREATED"}}
+	for _, order := range response.Data {
+		view.rows = append(view.rows, []string{
+			strconv.FormatInt(order.ID, 10),
+			order.ChannelOrderID,
+			order.CustomerName,
+			order.CustomerCountry,
+			order.Status,
+			order.CreatedAt,
+		})
+	}
+	return render(env.stdout, opts.output, response, view)
+}
+
+// formatAmount prints rates and charges with two decimals, the way the
+// Shiprocket panel shows them.
+func formatAmount(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
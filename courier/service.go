@@ -18,87 +18,93 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) AssignAWB(ctx context.Context, request *AssignAWBRequest) (*AssignAWBResponse, error) {
+func (s *Service) AssignAWB(ctx context.Context, request *AssignAWBRequest, opts ...internalclient.CallOption) (*AssignAWBResponse, error) {
 	var response AssignAWBResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/courier/assign/awb",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "courier.AssignAWB",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/courier/assign/awb",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) ListCouriers(ctx context.Context, params *CourierListParams) (*CourierListResponse, error) {
+func (s *Service) ListCouriers(ctx context.Context, params *CourierListParams, opts ...internalclient.CallOption) (*CourierListResponse, error) {
 	var response CourierListResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/courier/courierListWithCounts",
+		Operation: "courier.ListCouriers",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/courier/courierListWithCounts",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) CheckServiceability(ctx context.Context, params *ServiceabilityParams) (*ServiceabilityResponse, error) {
+func (s *Service) CheckServiceability(ctx context.Context, params *ServiceabilityParams, opts ...internalclient.CallOption) (*ServiceabilityResponse, error) {
 	var response ServiceabilityResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/courier/serviceability/",
+		Operation: "courier.CheckServiceability",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/courier/serviceability/",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GeneratePickup(ctx context.Context, request *GeneratePickupRequest) (*GeneratePickupResponse, error) {
+func (s *Service) GeneratePickup(ctx context.Context, request *GeneratePickupRequest, opts ...internalclient.CallOption) (*GeneratePickupResponse, error) {
 	var response GeneratePickupResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/courier/generate/pickup",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "courier.GeneratePickup",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/courier/generate/pickup",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) UploadBlockedPincodes(ctx context.Context, request *UploadBlockedPincodesRequest) (*UploadBlockedPincodesResponse, error) {
+func (s *Service) UploadBlockedPincodes(ctx context.Context, request *UploadBlockedPincodesRequest, opts ...internalclient.CallOption) (*UploadBlockedPincodesResponse, error) {
 	var response UploadBlockedPincodesResponse
 	if err := s.blockedPincodesClient().Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/blocked-pincodes/upload",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "courier.UploadBlockedPincodes",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/blocked-pincodes/upload",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GetBlockedPincodes(ctx context.Context, params *GetBlockedPincodesParams) (*GetBlockedPincodesResponse, error) {
+func (s *Service) GetBlockedPincodes(ctx context.Context, params *GetBlockedPincodesParams, opts ...internalclient.CallOption) (*GetBlockedPincodesResponse, error) {
 	var response GetBlockedPincodesResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/block-pincodes/get",
+		Operation: "courier.GetBlockedPincodes",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/block-pincodes/get",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.blockedPincodesClient().Do(ctx, request, &response); err != nil {
+	if err := s.blockedPincodesClient().Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 
@@ -111,14 +117,7 @@ func (s *Service) blockedPincodesClient() *internalclient.Client {
 		baseURL = blockedPincodesBaseURL
 	}
 
-	return internalclient.New(
-		baseURL,
-		internalclient.WithHTTPClient(s.client.HTTPClient),
-		internalclient.WithToken(s.client.Token),
-		internalclient.WithTokenSource(s.client.TokenSource),
-		internalclient.WithUserAgent(s.client.UserAgent),
-		internalclient.WithLogger(s.client.Logger),
-		internalclient.WithHooks(s.client.Hooks...),
-		internalclient.WithMiddleware(s.client.Middleware...),
-	)
+	client := s.client.Clone()
+	client.BaseURL = baseURL
+	return client
 }
@@ -0,0 +1,193 @@
+package documents
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/internal/pdf"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+)
+
+var (
+	ErrNoShipments = errors.New("at least one shipment ID is required")
+	ErrNoDocuments = errors.New("no documents were generated")
+)
+
+var defaultKinds = []Kind{KindLabel, KindInvoice}
+
+type Service struct {
+	shipments *shipment.Service
+}
+
+func NewService(client *internalclient.Client) *Service {
+	return &Service{shipments: shipment.NewService(client)}
+}
+
+// Print generates every requested document for the batch, downloads them one
+// at a time and writes a single merged PDF to w. Documents the API did not
+// create are reported in Result.NotCreated instead of failing the batch. When
+// nothing could be generated ErrNoDocuments is returned and w is left untouched.
+func (s *Service) Print(ctx context.Context, request *Request, w io.Writer) (*Result, error) {
+	if request == nil || len(request.ShipmentIDs) == 0 {
+		return nil, ErrNoShipments
+	}
+	kinds := request.Kinds
+	if len(kinds) == 0 {
+		kinds = defaultKinds
+	}
+
+	job := &printJob{
+		service:  s,
+		writer:   pdf.NewWriter(w),
+		result:   &Result{},
+		orderIDs: map[int64]int64{},
+	}
+
+	if request.Manifest == ManifestFirst {
+		if err := job.manifest(ctx, request.ShipmentIDs); err != nil {
+			return job.result, err
+		}
+	}
+	for _, shipmentID := range request.ShipmentIDs {
+		for _, kind := range kinds {
+			if err := job.document(ctx, shipmentID, kind); err != nil {
+				return job.result, err
+			}
+		}
+	}
+	if request.Manifest == ManifestLast {
+		if err := job.manifest(ctx, request.ShipmentIDs); err != nil {
+			return job.result, err
+		}
+	}
+
+	if job.writer.PageCount() == 0 {
+		return job.result, ErrNoDocuments
+	}
+	if err := job.writer.Close(); err != nil {
+		return job.result, err
+	}
+	job.result.Pages = job.writer.PageCount()
+
+	return job.result, nil
+}
+
+type printJob struct {
+	service  *Service
+	writer   *pdf.Writer
+	result   *Result
+	orderIDs map[int64]int64
+}
+
+func (j *printJob) manifest(ctx context.Context, shipmentIDs []int64) error {
+	response, err := j.service.shipments.GenerateManifest(ctx, &shipment.GenerateManifestRequest{ShipmentID: shipmentIDs})
+	if err != nil {
+		return err
+	}
+	if response.ManifestURL == "" {
+		j.result.NotCreated = append(j.result.NotCreated, NotCreated{Kind: KindManifest, Reason: "manifest URL was not returned"})
+		return nil
+	}
+	return j.append(ctx, Document{Kind: KindManifest, URL: response.ManifestURL})
+}
+
+func (j *printJob) document(ctx context.Context, shipmentID int64, kind Kind) error {
+	url, reason, err := j.generate(ctx, shipmentID, kind)
+	if err != nil {
+		return err
+	}
+	if url == "" {
+		j.result.NotCreated = append(j.result.NotCreated, NotCreated{ShipmentID: shipmentID, Kind: kind, Reason: reason})
+		return nil
+	}
+	return j.append(ctx, Document{ShipmentID: shipmentID, Kind: kind, URL: url})
+}
+
+func (j *printJob) generate(ctx context.Context, shipmentID int64, kind Kind) (string, string, error) {
+	shipments := j.service.shipments
+
+	switch kind {
+	case KindLabel:
+		response, err := shipments.GenerateLabel(ctx, &shipment.GenerateLabelRequest{ShipmentID: []int64{shipmentID}})
+		if err != nil {
+			return "", "", err
+		}
+		if response.LabelURL == "" || containsID(response.NotCreated, shipmentID) {
+			return "", reasonOrDefault(response.Response, "label was not created"), nil
+		}
+		return response.LabelURL, "", nil
+	case KindInvoice:
+		orderID, err := j.orderID(ctx, shipmentID)
+		if err != nil {
+			return "", "", err
+		}
+		response, err := shipments.GenerateInvoice(ctx, &shipment.GenerateInvoiceRequest{IDs: []int64{orderID}})
+		if err != nil {
+			return "", "", err
+		}
+		if response.InvoiceURL == "" || containsID(response.NotCreated, orderID) {
+			return "", fmt.Sprintf("invoice was not created for order %d", orderID), nil
+		}
+		return response.InvoiceURL, "", nil
+	case KindLabelInvoice:
+		response, err := shipments.GenerateCombinedLabelInvoice(ctx, &shipment.GenerateCombinedLabelInvoiceRequest{ShipmentIDs: []int64{shipmentID}})
+		if err != nil {
+			return "", "", err
+		}
+		if response.FileURL == "" {
+			reason := "combined label and invoice was not created"
+			if response.ErrorFileURL != "" {
+				reason += ", see " + response.ErrorFileURL
+			}
+			return "", reason, nil
+		}
+		return response.FileURL, "", nil
+	default:
+		return "", "", fmt.Errorf("unsupported document kind %q", kind)
+	}
+}
+
+func (j *printJob) orderID(ctx context.Context, shipmentID int64) (int64, error) {
+	if orderID, ok := j.orderIDs[shipmentID]; ok {
+		return orderID, nil
+	}
+	detail, err := j.service.shipments.Get(ctx, &shipment.GetRequest{ShipmentID: shipmentID})
+	if err != nil {
+		return 0, err
+	}
+	j.orderIDs[shipmentID] = detail.Data.OrderID
+	return detail.Data.OrderID, nil
+}
+
+func (j *printJob) append(ctx context.Context, document Document) error {
+	download, err := j.service.shipments.DownloadArtifact(ctx, document.URL)
+	if err != nil {
+		return err
+	}
+	pages, err := j.writer.Append(download.Body)
+	if err != nil {
+		return fmt.Errorf("merge %s for shipment %d: %w", document.Kind, document.ShipmentID, err)
+	}
+	document.Pages = pages
+	j.result.Documents = append(j.result.Documents, document)
+	return nil
+}
+
+func containsID(ids []shipment.FlexibleInt, id int64) bool {
+	for _, candidate := range ids {
+		if candidate.Int64() == id {
+			return true
+		}
+	}
+	return false
+}
+
+func reasonOrDefault(reason, fallback string) string {
+	if reason == "" {
+		return fallback
+	}
+	return reason
+}
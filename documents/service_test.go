@@ -0,0 +1,175 @@
+package documents
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+)
+
+func onePagePDF(text string) []byte {
+	content := "BT (" + text + ") Tj ET"
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	buf.WriteString("1 0 obj\n<< /Type /Catalog /Pages 2 0 R >>\nendobj\n")
+	buf.WriteString("2 0 obj\n<< /Type /Pages /Kids [3 0 R] /Count 1 >>\nendobj\n")
+	buf.WriteString("3 0 obj\n<< /Type /Page /Parent 2 0 R /MediaBox [0 0 288 432] /Contents 4 0 R >>\nendobj\n")
+	fmt.Fprintf(&buf, "4 0 obj\n<< /Length %d >>\nstream\n%s\nendstream\nendobj\n", len(content), content)
+	buf.WriteString("trailer\n<< /Size 5 /Root 1 0 R >>\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func TestPrintMergesDocumentsInRequestedOrder(t *testing.T) {
+	var calls []string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+
+		switch r.URL.Path {
+		case "/v1/external/manifests/generate":
+			assertJSONEqual(t, `{"shipment_id":[101,102]}`, string(body))
+			_, _ = fmt.Fprintf(w, `{"status":1,"manifest_url":"%s/files/manifest.pdf"}`, server.URL)
+		case "/v1/external/courier/generate/label":
+			var request struct {
+				ShipmentID []int64 `json:"shipment_id"`
+			}
+			_ = json.Unmarshal(body, &request)
+			if request.ShipmentID[0] == 102 {
+				_, _ = w.Write([]byte(`{"label_created":0,"label_url":"","response":"Label could not be generated","not_created":[102]}`))
+				return
+			}
+			_, _ = fmt.Fprintf(w, `{"label_created":1,"label_url":"%s/files/label-%d.pdf","response":"Label has been created and uploaded successfully!","not_created":[]}`, server.URL, request.ShipmentID[0])
+		case "/v1/external/shipments/101", "/v1/external/shipments/102":
+			id := strings.TrimPrefix(r.URL.Path, "/v1/external/shipments/")
+			_, _ = fmt.Fprintf(w, `{"data":{"id":%s,"order_id":%s0}}`, id, id)
+		case "/v1/external/orders/print/invoice":
+			var request struct {
+				IDs []int64 `json:"ids"`
+			}
+			_ = json.Unmarshal(body, &request)
+			_, _ = fmt.Fprintf(w, `{"is_invoice_created":true,"invoice_url":"%s/files/invoice-%d.pdf","not_created":[]}`, server.URL, request.IDs[0])
+		default:
+			if strings.HasPrefix(r.URL.Path, "/files/") {
+				w.Header().Set("Content-Type", "application/pdf")
+				name := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/files/"), ".pdf")
+				_, _ = w.Write(onePagePDF(name))
+				return
+			}
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+
+	var out bytes.Buffer
+	result, err := service.Print(context.Background(), &Request{
+		ShipmentIDs: []int64{101, 102},
+		Kinds:       []Kind{KindLabel, KindInvoice},
+		Manifest:    ManifestLast,
+	}, &out)
+	if err != nil {
+		t.Fatalf("Print returned error: %v", err)
+	}
+
+	if result.Pages != 4 || len(result.Documents) != 4 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.NotCreated) != 1 || result.NotCreated[0] != (NotCreated{ShipmentID: 102, Kind: KindLabel, Reason: "Label could not be generated"}) {
+		t.Fatalf("unexpected not created: %+v", result.NotCreated)
+	}
+
+	var order []string
+	for _, document := range result.Documents {
+		order = append(order, fmt.Sprintf("%s:%d", document.Kind, document.ShipmentID))
+	}
+	if strings.Join(order, ",") != "label:101,invoice:101,invoice:102,manifest:0" {
+		t.Fatalf("unexpected document order: %v", order)
+	}
+
+	merged := out.Bytes()
+	if !bytes.HasPrefix(merged, []byte("%PDF-")) || !bytes.HasSuffix(merged, []byte("%%EOF\n")) {
+		t.Fatalf("unexpected merged output: %q", merged)
+	}
+	var pageContents []string
+	for _, match := range regexp.MustCompile(`BT \(([^)]*)\) Tj ET`).FindAllSubmatch(merged, -1) {
+		pageContents = append(pageContents, string(match[1]))
+	}
+	if strings.Join(pageContents, ",") != "label-101,invoice-1010,invoice-1020,manifest" {
+		t.Fatalf("unexpected page contents: %v", pageContents)
+	}
+	if !bytes.Contains(merged, []byte("/Count 4")) {
+		t.Fatalf("expected a four page tree in output")
+	}
+
+	shipmentLookups := 0
+	for _, call := range calls {
+		if strings.HasPrefix(call, "GET /v1/external/shipments/") {
+			shipmentLookups++
+		}
+	}
+	if shipmentLookups != 2 {
+		t.Fatalf("unexpected shipment lookups: %v", calls)
+	}
+}
+
+func TestPrintReportsWhenNothingWasGenerated(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/external/courier/generate/label-invoice" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"completed":true,"file_url":"","error_file_url":"https://example.com/errors.csv","success_count":0,"error_count":1}`))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+
+	var out bytes.Buffer
+	result, err := service.Print(context.Background(), &Request{
+		ShipmentIDs: []int64{7},
+		Kinds:       []Kind{KindLabelInvoice},
+	}, &out)
+	if !errors.Is(err, ErrNoDocuments) {
+		t.Fatalf("expected ErrNoDocuments, got %v", err)
+	}
+	if out.Len() != 0 {
+		t.Fatalf("expected nothing written, got %q", out.String())
+	}
+	if len(result.NotCreated) != 1 || !strings.Contains(result.NotCreated[0].Reason, "errors.csv") {
+		t.Fatalf("unexpected not created: %+v", result.NotCreated)
+	}
+
+	if _, err := service.Print(context.Background(), &Request{}, &out); !errors.Is(err, ErrNoShipments) {
+		t.Fatalf("expected ErrNoShipments, got %v", err)
+	}
+}
+
+func assertJSONEqual(t *testing.T, expected string, actual string) {
+	t.Helper()
+
+	var expectedValue any
+	if err := json.Unmarshal([]byte(expected), &expectedValue); err != nil {
+		t.Fatalf("unmarshal expected json: %v", err)
+	}
+
+	var actualValue any
+	if err := json.Unmarshal([]byte(actual), &actualValue); err != nil {
+		t.Fatalf("unmarshal actual json: %v", err)
+	}
+
+	expectedJSON, _ := json.Marshal(expectedValue)
+	actualJSON, _ := json.Marshal(actualValue)
+	if string(expectedJSON) != string(actualJSON) {
+		t.Fatalf("unexpected json body:\nexpected: %s\nactual:   %s", expected, actual)
+	}
+}
@@ -0,0 +1,46 @@
+package documents
+
+type Kind string
+
+const (
+	KindLabel        Kind = "label"
+	KindInvoice      Kind = "invoice"
+	KindLabelInvoice Kind = "label_invoice"
+	KindManifest     Kind = "manifest"
+)
+
+type ManifestPosition string
+
+const (
+	ManifestNone  ManifestPosition = ""
+	ManifestFirst ManifestPosition = "first"
+	ManifestLast  ManifestPosition = "last"
+)
+
+// Request describes a batch of shipments to print. Kinds is the per-shipment
+// document order and defaults to label then invoice. The manifest covers the
+// whole batch and is placed before or after the shipment documents.
+type Request struct {
+	ShipmentIDs []int64
+	Kinds       []Kind
+	Manifest    ManifestPosition
+}
+
+type Document struct {
+	ShipmentID int64
+	Kind       Kind
+	URL        string
+	Pages      int
+}
+
+type NotCreated struct {
+	ShipmentID int64
+	Kind       Kind
+	Reason     string
+}
+
+type Result struct {
+	Documents  []Document
+	NotCreated []NotCreated
+	Pages      int
+}
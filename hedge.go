@@ -0,0 +1,271 @@
+package shiprocket
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	defaultHedgePercentile = 0.95
+	defaultHedgeDelay      = time.Second
+	defaultHedgeMinSamples = 20
+	defaultHedgeBudget     = 0.05
+	hedgeSampleSize        = 128
+)
+
+// HedgingPolicy configures a Hedger. Only GET and HEAD requests without a
+// body are hedged.
+type HedgingPolicy struct {
+	// Operations limits hedging to these operation names, such as
+	// "shipment.TrackByAWB". Empty hedges every read.
+	Operations []string
+	// Percentile of recent latencies, per operation, after which the hedge
+	// request is sent. Defaults to 0.95.
+	Percentile float64
+	// Delay is used until MinSamples latencies have been observed for an
+	// operation. Defaults to one second.
+	Delay time.Duration
+	// MinSamples defaults to 20.
+	MinSamples int
+	// Budget caps hedge requests as a fraction of hedgeable requests.
+	// Defaults to 0.05, so at most 5% extra traffic.
+	Budget float64
+}
+
+type HedgeStats struct {
+	Requests int64
+	Hedged   int64
+	// HedgeWins counts hedge requests that answered first.
+	HedgeWins int64
+}
+
+// Hedger cuts tail latency of reads by sending a second request when the
+// first is slower than usual, returning whichever succeeds first and
+// cancelling the other. Add it to Config.Middleware with Middleware.
+type Hedger struct {
+	policy     HedgingPolicy
+	operations map[string]bool
+
+	mu      sync.Mutex
+	samples map[string]*latencySamples
+	stats   HedgeStats
+}
+
+type latencySamples struct {
+	values []time.Duration
+	next   int
+}
+
+type hedgeResult struct {
+	resp    *http.Response
+	err     error
+	cancel  context.CancelFunc
+	attempt int
+}
+
+func NewHedger(policy HedgingPolicy) *Hedger {
+	if policy.Percentile <= 0 || policy.Percentile > 1 {
+		policy.Percentile = defaultHedgePercentile
+	}
+	if policy.Delay <= 0 {
+		policy.Delay = defaultHedgeDelay
+	}
+	if policy.MinSamples <= 0 {
+		policy.MinSamples = defaultHedgeMinSamples
+	}
+	if policy.Budget <= 0 {
+		policy.Budget = defaultHedgeBudget
+	}
+	h := &Hedger{policy: policy, samples: map[string]*latencySamples{}}
+	if len(policy.Operations) > 0 {
+		h.operations = make(map[string]bool, len(policy.Operations))
+		for _, name := range policy.Operations {
+			h.operations[name] = true
+		}
+	}
+	return h
+}
+
+func (h *Hedger) Middleware() Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			key, ok := h.hedgeable(req)
+			if !ok {
+				return next.RoundTrip(req)
+			}
+			return h.roundTrip(next, req, key)
+		})
+	}
+}
+
+func (h *Hedger) Stats() HedgeStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.stats
+}
+
+func (h *Hedger) hedgeable(req *http.Request) (string, bool) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return "", false
+	}
+	if req.Body != nil && req.Body != http.NoBody {
+		return "", false
+	}
+	key := req.Method + " " + req.URL.Path
+	if op, ok := OperationFromContext(req.Context()); ok && op.Name != "" {
+		key = op.Name
+	}
+	if h.operations != nil && !h.operations[key] {
+		return "", false
+	}
+	return key, true
+}
+
+func (h *Hedger) roundTrip(next http.RoundTripper, req *http.Request, key string) (*http.Response, error) {
+	h.mu.Lock()
+	h.stats.Requests++
+	delay := h.delay(key)
+	h.mu.Unlock()
+
+	started := time.Now()
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	launch := func() {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := len(cancels)
+		cancels = append(cancels, cancel)
+		clone := req.Clone(ctx)
+		go func() {
+			resp, err := next.RoundTrip(clone)
+			results <- hedgeResult{resp: resp, err: err, cancel: cancel, attempt: attempt}
+		}()
+	}
+
+	launch()
+	pending := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	var failed *hedgeResult
+	for {
+		select {
+		case <-timer.C:
+			if h.allowHedge() {
+				launch()
+				pending++
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil && result.resp.StatusCode < http.StatusInternalServerError && result.resp.StatusCode != http.StatusTooManyRequests {
+				h.finish(key, time.Since(started), result.attempt > 0)
+				if failed != nil {
+					closeHedge(*failed)
+				}
+				discardHedges(results, pending, cancels, result.attempt)
+				return hedgeResponse(result), nil
+			}
+			if pending == 0 {
+				if failed != nil {
+					closeHedge(*failed)
+				}
+				if result.err != nil {
+					result.cancel()
+					return nil, result.err
+				}
+				return hedgeResponse(result), nil
+			}
+			// Keep the failure as the answer in case the other request
+			// fails too.
+			failed = &result
+		}
+	}
+}
+
+// delay must be called with h.mu held.
+func (h *Hedger) delay(key string) time.Duration {
+	samples := h.samples[key]
+	if samples == nil || len(samples.values) < h.policy.MinSamples {
+		return h.policy.Delay
+	}
+	sorted := append([]time.Duration(nil), samples.values...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	index := int(float64(len(sorted)-1) * h.policy.Percentile)
+	return sorted[index]
+}
+
+func (h *Hedger) allowHedge() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if float64(h.stats.Hedged+1) > h.policy.Budget*float64(h.stats.Requests) {
+		return false
+	}
+	h.stats.Hedged++
+	return true
+}
+
+func (h *Hedger) finish(key string, latency time.Duration, hedgeWon bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if hedgeWon {
+		h.stats.HedgeWins++
+	}
+	samples := h.samples[key]
+	if samples == nil {
+		samples = &latencySamples{}
+		h.samples[key] = samples
+	}
+	if len(samples.values) < hedgeSampleSize {
+		samples.values = append(samples.values, latency)
+		return
+	}
+	samples.values[samples.next] = latency
+	samples.next = (samples.next + 1) % hedgeSampleSize
+}
+
+// discardHedges cancels the requests that lost the race and releases their
+// connections once they return.
+func discardHedges(results <-chan hedgeResult, pending int, cancels []context.CancelFunc, winner int) {
+	if pending == 0 {
+		return
+	}
+	for attempt, cancel := range cancels {
+		if attempt != winner {
+			cancel()
+		}
+	}
+	go func() {
+		for i := 0; i < pending; i++ {
+			closeHedge(<-results)
+		}
+	}()
+}
+
+func closeHedge(result hedgeResult) {
+	if result.resp != nil {
+		_, _ = io.Copy(io.Discard, result.resp.Body)
+		_ = result.resp.Body.Close()
+	}
+	result.cancel()
+}
+
+// hedgeResponse ties the attempt's context to the response body, so the
+// context is released only once the caller has read the response.
+func hedgeResponse(result hedgeResult) *http.Response {
+	result.resp.Body = &hedgeBody{ReadCloser: result.resp.Body, cancel: result.cancel}
+	return result.resp
+}
+
+type hedgeBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *hedgeBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,100 @@
+package shiprocket
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedgerReturnsFirstSuccessAndCancelsTheSlowRequest(t *testing.T) {
+	var calls atomic.Int32
+	slowCancelled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			select {
+			case <-r.Context().Done():
+				close(slowCancelled)
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1}}`))
+	}))
+	defer server.Close()
+
+	hedger := NewHedger(HedgingPolicy{Operations: []string{"shipment.TrackByAWB"}, Delay: 20 * time.Millisecond, Budget: 1})
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", Middleware: []Middleware{hedger.Middleware()}})
+
+	var out map[string]any
+	started := time.Now()
+	err := client.Do(context.Background(), &Request{
+		Operation:  "shipment.TrackByAWB",
+		Method:     http.MethodGet,
+		Path:       "/v1/external/courier/track/awb/{awb}",
+		PathParams: map[string]string{"awb": "AWB1"},
+	}, &out)
+	if err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 2*time.Second {
+		t.Fatalf("expected hedge to answer quickly, took %s", elapsed)
+	}
+	if out["tracking_data"] == nil {
+		t.Fatalf("unexpected response %v", out)
+	}
+
+	select {
+	case <-slowCancelled:
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected the slow request to be cancelled")
+	}
+	if stats := hedger.Stats(); stats.Requests != 1 || stats.Hedged != 1 || stats.HedgeWins != 1 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+}
+
+func TestHedgerRespectsBudgetAndSkipsWrites(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(30 * time.Millisecond)
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	hedger := NewHedger(HedgingPolicy{Delay: time.Millisecond, Budget: 0.5})
+	client := NewClient(Config{BaseURL: server.URL, Token: "token", Middleware: []Middleware{hedger.Middleware()}})
+
+	for i := 0; i < 4; i++ {
+		if err := client.Do(context.Background(), &Request{Operation: "courier.CheckServiceability", Method: http.MethodGet, Path: "/v1/external/courier/serviceability/"}, nil); err != nil {
+			t.Fatalf("read %d: %v", i, err)
+		}
+	}
+	if stats := hedger.Stats(); stats.Requests != 4 || stats.Hedged != 2 {
+		t.Fatalf("expected budget to allow 2 hedges in 4 requests, got %+v", stats)
+	}
+
+	before := calls.Load()
+	if err := client.Do(context.Background(), &Request{Operation: "orders.CreateCustomOrder", Method: http.MethodPost, Path: "/v1/external/orders/create/adhoc", JSONBody: map[string]string{}}, nil); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if calls.Load()-before != 1 || hedger.Stats().Requests != 4 {
+		t.Fatalf("expected writes not to be hedged, got %d calls", calls.Load()-before)
+	}
+}
+
+func TestHedgerDelayUsesObservedPercentile(t *testing.T) {
+	hedger := NewHedger(HedgingPolicy{Percentile: 0.9, MinSamples: 10, Delay: time.Second})
+	if got := hedger.delay("shipment.TrackByAWB"); got != time.Second {
+		t.Fatalf("expected fallback delay, got %s", got)
+	}
+	for i := 1; i <= 10; i++ {
+		hedger.finish("shipment.TrackByAWB", time.Duration(i)*10*time.Millisecond, false)
+	}
+	if got := hedger.delay("shipment.TrackByAWB"); got != 90*time.Millisecond {
+		t.Fatalf("expected p90 of samples, got %s", got)
+	}
+}
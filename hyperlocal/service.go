@@ -26,27 +26,27 @@ func NewService(client *internalclient.Client) *Service {
 	}
 }
 
-func (s *Service) CreateOrder(ctx context.Context, request *orders.CreateCustomOrderRequest) (*orders.CustomOrderResponse, error) {
-	return s.orders.CreateCustomOrder(ctx, request)
+func (s *Service) CreateOrder(ctx context.Context, request *orders.CreateCustomOrderRequest, opts ...internalclient.CallOption) (*orders.CustomOrderResponse, error) {
+	return s.orders.CreateCustomOrder(ctx, request, opts...)
 }
 
-func (s *Service) ListOrders(ctx context.Context, params *orders.OrdersListParams) (*orders.OrdersListResponse, error) {
-	return s.orders.GetOrdersWithParams(ctx, params)
+func (s *Service) ListOrders(ctx context.Context, params *orders.OrdersListParams, opts ...internalclient.CallOption) (*orders.OrdersListResponse, error) {
+	return s.orders.GetOrdersWithParams(ctx, params, opts...)
 }
 
-func (s *Service) GetOrderDetails(ctx context.Context, request *orders.GetOrderDetailsRequest) (orders.OrderDetailResponse, error) {
-	return s.orders.GetOrderDetails(ctx, request)
+func (s *Service) GetOrderDetails(ctx context.Context, request *orders.GetOrderDetailsRequest, opts ...internalclient.CallOption) (orders.OrderDetailResponse, error) {
+	return s.orders.GetOrderDetails(ctx, request, opts...)
 }
 
-func (s *Service) ExportOrders(ctx context.Context, request *orders.ExportOrdersRequest) (*orders.ExportOrdersResponse, error) {
-	return s.orders.ExportOrders(ctx, request)
+func (s *Service) ExportOrders(ctx context.Context, request *orders.ExportOrdersRequest, opts ...internalclient.CallOption) (*orders.ExportOrdersResponse, error) {
+	return s.orders.ExportOrders(ctx, request, opts...)
 }
 
-func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest) (*courier.AssignAWBResponse, error) {
-	return s.couriers.AssignAWB(ctx, request)
+func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest, opts ...internalclient.CallOption) (*courier.AssignAWBResponse, error) {
+	return s.couriers.AssignAWB(ctx, request, opts...)
 }
 
-func (s *Service) CheckServiceability(ctx context.Context, params *courier.ServiceabilityParams) (*courier.ServiceabilityResponse, error) {
+func (s *Service) CheckServiceability(ctx context.Context, params *courier.ServiceabilityParams, opts ...internalclient.CallOption) (*courier.ServiceabilityResponse, error) {
 	if params == nil {
 		params = &courier.ServiceabilityParams{}
 	}
@@ -54,29 +54,29 @@ func (s *Service) CheckServiceability(ctx context.Context, params *courier.Servi
 		isHyperlocal := true
 		params.IsNewHyperlocal = &isHyperlocal
 	}
-	return s.couriers.CheckServiceability(ctx, params)
+	return s.couriers.CheckServiceability(ctx, params, opts...)
 }
 
-func (s *Service) TrackByAWB(ctx context.Context, request *shipment.TrackByAWBRequest) (*shipment.TrackingResponse, error) {
-	return s.shipments.TrackByAWB(ctx, request)
+func (s *Service) TrackByAWB(ctx context.Context, request *shipment.TrackByAWBRequest, opts ...internalclient.CallOption) (*shipment.TrackingResponse, error) {
+	return s.shipments.TrackByAWB(ctx, request, opts...)
 }
 
-func (s *Service) TrackByAWBs(ctx context.Context, request *shipment.TrackByAWBsRequest) (shipment.MultiTrackingResponse, error) {
-	return s.shipments.TrackByAWBs(ctx, request)
+func (s *Service) TrackByAWBs(ctx context.Context, request *shipment.TrackByAWBsRequest, opts ...internalclient.CallOption) (shipment.MultiTrackingResponse, error) {
+	return s.shipments.TrackByAWBs(ctx, request, opts...)
 }
 
-func (s *Service) TrackByShipmentID(ctx context.Context, request *shipment.TrackByShipmentIDRequest) (*shipment.TrackingResponse, error) {
-	return s.shipments.TrackByShipmentID(ctx, request)
+func (s *Service) TrackByShipmentID(ctx context.Context, request *shipment.TrackByShipmentIDRequest, opts ...internalclient.CallOption) (*shipment.TrackingResponse, error) {
+	return s.shipments.TrackByShipmentID(ctx, request, opts...)
 }
 
-func (s *Service) TrackByOrder(ctx context.Context, request *shipment.TrackByOrderRequest) (shipment.OrderTrackingResponse, error) {
-	return s.shipments.TrackByOrder(ctx, request)
+func (s *Service) TrackByOrder(ctx context.Context, request *shipment.TrackByOrderRequest, opts ...internalclient.CallOption) (shipment.OrderTrackingResponse, error) {
+	return s.shipments.TrackByOrder(ctx, request, opts...)
 }
 
-func (s *Service) ListPickupAddresses(ctx context.Context) (*pickupaddress.ListResponse, error) {
-	return s.pickupAddresses.List(ctx)
+func (s *Service) ListPickupAddresses(ctx context.Context, opts ...internalclient.CallOption) (*pickupaddress.ListResponse, error) {
+	return s.pickupAddresses.List(ctx, opts...)
 }
 
-func (s *Service) CreatePickupAddress(ctx context.Context, request *pickupaddress.CreateRequest) (*pickupaddress.CreateResponse, error) {
-	return s.pickupAddresses.Create(ctx, request)
+func (s *Service) CreatePickupAddress(ctx context.Context, request *pickupaddress.CreateRequest, opts ...internalclient.CallOption) (*pickupaddress.CreateResponse, error) {
+	return s.pickupAddresses.Create(ctx, request, opts...)
 }
@@ -0,0 +1,116 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// CallOption adjusts a single SDK call. Service methods accept them as
+// trailing variadic arguments.
+type CallOption func(*Request)
+
+// WithCallTimeout bounds the whole call, including retries and reading the
+// response body.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(req *Request) {
+		req.Timeout = timeout
+	}
+}
+
+func WithHeader(key, value string) CallOption {
+	return func(req *Request) {
+		if req.Headers == nil {
+			req.Headers = http.Header{}
+		}
+		req.Headers.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sends key in the Idempotency-Key header and allows the
+// retry policy to repeat non-idempotent methods such as POST.
+func WithIdempotencyKey(key string) CallOption {
+	return func(req *Request) {
+		req.IdempotencyKey = key
+	}
+}
+
+// WithRetryPolicy overrides the client retry policy for one call. Pass
+// NoRetries to disable retries.
+func WithRetryPolicy(policy *RetryPolicy) CallOption {
+	return func(req *Request) {
+		req.Retry = policy
+	}
+}
+
+// CaptureResponseMeta copies the metadata of the final response into meta.
+func CaptureResponseMeta(meta *ResponseMeta) CallOption {
+	return func(req *Request) {
+		req.ResponseMeta = meta
+	}
+}
+
+// SharedCallOptions wraps opts for methods that send several requests. The
+// timeout, headers and retry policy reach every request, but an idempotency
+// key or response meta target is dropped: one key on different requests
+// would collide, and each request would overwrite the others' meta.
+func SharedCallOptions(opts []CallOption) []CallOption {
+	if len(opts) == 0 {
+		return nil
+	}
+	return []CallOption{func(req *Request) {
+		key, meta := req.IdempotencyKey, req.ResponseMeta
+		for _, opt := range opts {
+			if opt != nil {
+				opt(req)
+			}
+		}
+		req.IdempotencyKey, req.ResponseMeta = key, meta
+	}}
+}
+
+// applyCallOptions returns a copy of req with opts applied so callers can
+// reuse their Request values.
+func applyCallOptions(req *Request, opts []CallOption) *Request {
+	if req == nil || len(opts) == 0 {
+		return req
+	}
+
+	applied := *req
+	applied.Headers = req.Headers.Clone()
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&applied)
+		}
+	}
+	return &applied
+}
+
+func (c *Client) retryPolicy(req *Request) *RetryPolicy {
+	if req.Retry != nil {
+		return req.Retry
+	}
+	return c.Retry
+}
+
+func callContext(ctx context.Context, req *Request) (context.Context, context.CancelFunc) {
+	if req == nil || req.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, req.Timeout)
+}
+
+// cancelOnClose ties a call timeout to a response body that outlives the call.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"mime/multipart"
 	"net/http"
 	"net/url"
@@ -40,11 +42,23 @@ type Client struct {
 	HTTPClient  *http.Client
 	UserAgent   string
 	Logger      Logger
+	Slog        *slog.Logger
+	LogBodies   bool
+	Redactor    *Redactor
 	Hooks       []Hook
 	Middleware  []Middleware
+	Observers   []Observer
+	// Retry is the default retry policy. Nil disables retries.
+	Retry *RetryPolicy
+	// Tenant is added to the context and Operation of every call, before
+	// observers and hooks run.
+	Tenant string
 }
 
 type Request struct {
+	// Operation names the SDK method issuing the request, such as
+	// "shipment.TrackByAWB". It is reported to observers.
+	Operation    string
 	Method       string
 	Path         string
 	PathParams   map[string]string
@@ -55,6 +69,12 @@ type Request struct {
 	ContentType  string
 	Multipart    *MultipartBody
 	ExpectedCode []int
+
+	// Per-call settings, usually set through CallOption values.
+	Timeout        time.Duration
+	IdempotencyKey string
+	Retry          *RetryPolicy
+	ResponseMeta   *ResponseMeta
 }
 
 type MultipartBody struct {
@@ -125,7 +145,8 @@ func WithUserAgent(userAgent string) Option {
 
 func WithTimeout(timeout time.Duration) Option {
 	return func(c *Client) {
-		if c.HTTPClient == nil {
+		// Never set a timeout on the shared http.DefaultClient.
+		if c.HTTPClient == nil || c.HTTPClient == http.DefaultClient {
 			c.HTTPClient = &http.Client{}
 		}
 		c.HTTPClient.Timeout = timeout
@@ -138,6 +159,31 @@ func WithLogger(logger Logger) Option {
 	}
 }
 
+func WithRetry(policy *RetryPolicy) Option {
+	return func(c *Client) {
+		c.Retry = policy
+	}
+}
+
+func WithSlog(logger *slog.Logger) Option {
+	return func(c *Client) {
+		c.Slog = logger
+	}
+}
+
+// WithLogBodies logs redacted JSON request and response bodies at debug level.
+func WithLogBodies(enabled bool) Option {
+	return func(c *Client) {
+		c.LogBodies = enabled
+	}
+}
+
+func WithRedactor(redactor *Redactor) Option {
+	return func(c *Client) {
+		c.Redactor = redactor
+	}
+}
+
 func WithHooks(hooks ...Hook) Option {
 	return func(c *Client) {
 		c.Hooks = append(c.Hooks, hooks...)
@@ -150,6 +196,20 @@ func WithMiddleware(middleware ...Middleware) Option {
 	}
 }
 
+func WithObservers(observers ...Observer) Option {
+	return func(c *Client) {
+		c.Observers = append(c.Observers, observers...)
+	}
+}
+
+// Clone returns a copy of c sharing its HTTP client, logging, hooks,
+// middleware, observers and retry policy, for requests that need a different
+// base URL or credentials.
+func (c *Client) Clone() *Client {
+	clone := *c
+	return &clone
+}
+
 func (c *Client) NewRequest(ctx context.Context, req *Request) (*http.Request, error) {
 	if req == nil {
 		return nil, fmt.Errorf("request is required")
@@ -187,11 +247,13 @@ func (c *Client) NewRequest(ctx context.Context, req *Request) (*http.Request, e
 
 	httpReq, err := http.NewRequestWithContext(ctx, method, rawURL.String(), body)
 	if err != nil {
+		closeBody(body)
 		return nil, err
 	}
 
 	token, err := c.resolveToken(ctx)
 	if err != nil {
+		closeBody(body)
 		return nil, err
 	}
 	if token != "" {
@@ -203,6 +265,9 @@ func (c *Client) NewRequest(ctx context.Context, req *Request) (*http.Request, e
 	if contentType != "" {
 		httpReq.Header.Set("Content-Type", contentType)
 	}
+	if req.IdempotencyKey != "" {
+		httpReq.Header.Set(IdempotencyKeyHeader, req.IdempotencyKey)
+	}
 	for key, values := range req.Headers {
 		for _, value := range values {
 			httpReq.Header.Add(key, value)
@@ -212,42 +277,69 @@ func (c *Client) NewRequest(ctx context.Context, req *Request) (*http.Request, e
 	return httpReq, nil
 }
 
-func (c *Client) Do(ctx context.Context, req *Request, out any) error {
-	resp, err := c.DoRaw(ctx, req)
+func (c *Client) Do(ctx context.Context, req *Request, out any, opts ...CallOption) error {
+	req = applyCallOptions(req, opts)
+	ctx, cancel := callContext(ctx, req)
+	defer cancel()
+
+	ctx, run := c.startOperation(ctx, req)
+	resp, err := c.send(ctx, run, req)
 	if err != nil {
+		run.end(nil, err)
 		return err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	return DecodeResponse(resp, out, req.ExpectedCode...)
+	err = DecodeResponse(resp, out, req.ExpectedCode...)
+	run.end(resp, err)
+	return err
 }
 
-func (c *Client) DoBytes(ctx context.Context, req *Request) ([]byte, error) {
-	resp, err := c.DoRaw(ctx, req)
+func (c *Client) DoBytes(ctx context.Context, req *Request, opts ...CallOption) ([]byte, error) {
+	req = applyCallOptions(req, opts)
+	ctx, cancel := callContext(ctx, req)
+	defer cancel()
+
+	ctx, run := c.startOperation(ctx, req)
+	resp, err := c.send(ctx, run, req)
 	if err != nil {
+		run.end(nil, err)
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if !isExpectedStatus(resp.StatusCode, req.ExpectedCode) {
-		return nil, newAPIError(resp)
+		err = newAPIError(resp)
+		run.end(resp, err)
+		return nil, err
 	}
 
-	return io.ReadAll(resp.Body)
+	body, err := io.ReadAll(resp.Body)
+	run.end(resp, err)
+	return body, err
 }
 
-func (c *Client) DoDownload(ctx context.Context, req *Request) (*Download, error) {
-	resp, err := c.DoRaw(ctx, req)
+func (c *Client) DoDownload(ctx context.Context, req *Request, opts ...CallOption) (*Download, error) {
+	req = applyCallOptions(req, opts)
+	ctx, cancel := callContext(ctx, req)
+	defer cancel()
+
+	ctx, run := c.startOperation(ctx, req)
+	resp, err := c.send(ctx, run, req)
 	if err != nil {
+		run.end(nil, err)
 		return nil, err
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	if !isExpectedStatus(resp.StatusCode, req.ExpectedCode) {
-		return nil, newAPIError(resp)
+		err = newAPIError(resp)
+		run.end(resp, err)
+		return nil, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
+	run.end(resp, err)
 	if err != nil {
 		return nil, err
 	}
@@ -261,11 +353,54 @@ func (c *Client) DoDownload(ctx context.Context, req *Request) (*Download, error
 	}, nil
 }
 
-func (c *Client) DoRaw(ctx context.Context, req *Request) (*http.Response, error) {
-	httpReq, err := c.NewRequest(ctx, req)
+// DoRaw returns the response without checking its status. Observers see the
+// status code but no error for non-2xx responses. A call timeout stays in
+// effect until the response body is closed.
+func (c *Client) DoRaw(ctx context.Context, req *Request, opts ...CallOption) (*http.Response, error) {
+	req = applyCallOptions(req, opts)
+	ctx, cancel := callContext(ctx, req)
+
+	ctx, run := c.startOperation(ctx, req)
+	resp, err := c.send(ctx, run, req)
+	run.end(resp, err)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// send performs the attempts of one operation, retrying according to the
+// call or client retry policy.
+func (c *Client) send(ctx context.Context, run *operationRun, req *Request) (*http.Response, error) {
+	policy := c.retryPolicy(req)
+	for {
+		resp, err := c.sendAttempt(ctx, run, req)
+		if !policy.shouldRetry(run.attempts, req, resp, err) {
+			return resp, err
+		}
+
+		delay := policy.backoff(run.attempts, resp)
+		discardBody(resp)
+		if c.Logger != nil {
+			c.Logger.Printf("shiprocket retrying %s %s in %s", req.Method, req.Path, delay)
+		}
+		if waitErr := sleepContext(ctx, delay); waitErr != nil {
+			if err == nil {
+				err = &TransportError{Err: waitErr, Method: req.Method, URL: c.BaseURL + req.Path}
+			}
+			return nil, err
+		}
+	}
+}
+
+func (c *Client) sendAttempt(ctx context.Context, run *operationRun, req *Request) (*http.Response, error) {
+	httpReq, err := c.NewRequest(run.attempt(ctx), req)
 	if err != nil {
+		closeBody(req.RawBody)
 		return nil, &TransportError{
-			Err:    err,
+			Err:    &buildError{err: err},
 			Method: req.Method,
 			URL:    c.BaseURL + req.Path,
 		}
@@ -275,17 +410,32 @@ func (c *Client) DoRaw(ctx context.Context, req *Request) (*http.Response, error
 		hook.Before(httpReq)
 	}
 
-	if c.Logger != nil {
-		c.Logger.Printf("shiprocket request %s %s", httpReq.Method, httpReq.URL.String())
-	}
+	c.logRequest(httpReq, req)
+	started := time.Now()
 
 	resp, err := c.httpClient().Do(httpReq)
 
 	for _, hook := range c.Hooks {
 		hook.After(resp, err)
 	}
+	c.logResponse(httpReq, resp, err, time.Since(started), !run.streamed)
+	if resp != nil {
+		captureResponseMeta(ctx, resp)
+		if req.ResponseMeta != nil {
+			*req.ResponseMeta = responseMeta(resp)
+		}
+	}
 
 	if err != nil {
+		// Middleware that fails before calling the transport leaves the
+		// body open, which would block a streaming body's writer forever.
+		if httpReq.Body != nil {
+			_ = httpReq.Body.Close()
+		}
+		var circuitErr *CircuitOpenError
+		if errors.As(err, &circuitErr) {
+			return nil, circuitErr
+		}
 		return nil, &TransportError{
 			Err:    err,
 			Method: httpReq.Method,
@@ -338,28 +488,15 @@ func (c *Client) httpClient() *http.Client {
 
 func buildBody(req *Request) (io.Reader, string, error) {
 	if req.Multipart != nil {
-		var body bytes.Buffer
-		writer := multipart.NewWriter(&body)
-
-		for key, value := range req.Multipart.Fields {
-			if err := writer.WriteField(key, value); err != nil {
-				return nil, "", err
-			}
-		}
-		for _, file := range req.Multipart.Files {
-			part, err := writer.CreateFormFile(file.FieldName, file.FileName)
-			if err != nil {
-				return nil, "", err
-			}
-			if _, err := io.Copy(part, file.Reader); err != nil {
-				return nil, "", err
-			}
-		}
-		if err := writer.Close(); err != nil {
-			return nil, "", err
-		}
-
-		return &body, writer.FormDataContentType(), nil
+		// The form is written as the transport reads it, so large files are
+		// never held in memory. sendAttempt closes the reader when the
+		// request fails, which stops the writer early.
+		reader, writer := io.Pipe()
+		form := multipart.NewWriter(writer)
+		go func() {
+			_ = writer.CloseWithError(writeMultipart(form, req.Multipart))
+		}()
+		return reader, form.FormDataContentType(), nil
 	}
 
 	if req.RawBody != nil {
@@ -378,6 +515,34 @@ func buildBody(req *Request) (io.Reader, string, error) {
 	return bytes.NewReader(body), "application/json", nil
 }
 
+// closeBody releases a streamed body that will never be sent.
+func closeBody(body io.Reader) {
+	if pipe, ok := body.(*io.PipeReader); ok {
+		_ = pipe.Close()
+	}
+}
+
+func writeMultipart(writer *multipart.Writer, body *MultipartBody) error {
+	for key, value := range body.Fields {
+		if err := writer.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+	for _, file := range body.Files {
+		if file.Reader == nil {
+			return fmt.Errorf("multipart file %q has no reader", file.FieldName)
+		}
+		part, err := writer.CreateFormFile(file.FieldName, file.FileName)
+		if err != nil {
+			return err
+		}
+		if _, err := io.Copy(part, file.Reader); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
 func isExpectedStatus(statusCode int, expectedCodes []int) bool {
 	if len(expectedCodes) == 0 {
 		return statusCode >= 200 && statusCode < 300
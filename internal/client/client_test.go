@@ -1,14 +1,25 @@
 package client
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"testing/iotest"
 	"time"
 )
 
@@ -68,6 +79,51 @@ func TestNewRequestBuildsJSONQueryAndPathParams(t *testing.T) {
 	}
 }
 
+func TestDoStreamsMultipartBodies(t *testing.T) {
+	payload := strings.Repeat("0123456789abcdef", 1<<16)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength != -1 {
+			t.Fatalf("expected a streamed body, got Content-Length %d", r.ContentLength)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile returned error: %v", err)
+		}
+		body, _ := io.ReadAll(file)
+		if string(body) != payload || r.FormValue("channel_id") != "7" {
+			t.Fatalf("unexpected upload: %d bytes, channel_id=%q", len(body), r.FormValue("channel_id"))
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithToken("secret"))
+	upload := func(reader io.Reader) error {
+		return client.Do(context.Background(), &Request{
+			Method: http.MethodPost,
+			Path:   "/upload",
+			Multipart: &MultipartBody{
+				Fields: map[string]string{"channel_id": "7"},
+				Files:  []MultipartFile{{FieldName: "file", FileName: "large.csv", Reader: reader}},
+			},
+		}, nil)
+	}
+	if err := upload(strings.NewReader(payload)); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	readErr := errors.New("disk unplugged")
+	err := upload(io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(readErr)))
+	var transportErr *TransportError
+	if !errors.As(err, &transportErr) || !errors.Is(err, readErr) {
+		t.Fatalf("expected TransportError wrapping the read error, got %v", err)
+	}
+}
+
 func TestDoHandlesMultipartAndAcceptedResponses(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
@@ -149,6 +205,9 @@ func TestWithTimeoutSetsHTTPClientTimeout(t *testing.T) {
 	if client.HTTPClient.Timeout != 5*time.Second {
 		t.Fatalf("unexpected timeout: %s", client.HTTPClient.Timeout)
 	}
+	if http.DefaultClient.Timeout != 0 {
+		t.Fatalf("WithTimeout modified http.DefaultClient: %s", http.DefaultClient.Timeout)
+	}
 }
 
 func TestDoSupportsNoBodyGET(t *testing.T) {
@@ -227,6 +286,162 @@ func TestDoDownloadReturnsPrintableArtifactMetadata(t *testing.T) {
 	}
 }
 
+func TestDoStreamComputesChecksumAndEnforcesMaxSize(t *testing.T) {
+	payload := []byte(strings.Repeat("manifest-row\n", 64))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/chunked" {
+			w.(http.Flusher).Flush()
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write(payload)
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	stream, err := client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "/export.csv"}, nil)
+	if err != nil {
+		t.Fatalf("DoStream returned error: %v", err)
+	}
+	body, err := io.ReadAll(stream)
+	_ = stream.Close()
+	if err != nil {
+		t.Fatalf("read stream: %v", err)
+	}
+	sum := sha256.Sum256(payload)
+	if !bytes.Equal(body, payload) || stream.SHA256() != hex.EncodeToString(sum[:]) {
+		t.Fatalf("unexpected stream body or checksum: %s", stream.SHA256())
+	}
+	if stream.ContentLength != int64(len(payload)) || stream.BytesRead() != int64(len(payload)) || stream.FileName != "export.csv" {
+		t.Fatalf("unexpected stream metadata: %+v", stream)
+	}
+
+	_, err = client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "/export.csv"}, &StreamOptions{MaxSize: 100})
+	if !errors.Is(err, ErrDownloadTooLarge) {
+		t.Fatalf("expected ErrDownloadTooLarge from content length, got %v", err)
+	}
+
+	stream, err = client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "/chunked"}, &StreamOptions{MaxSize: 100})
+	if err != nil {
+		t.Fatalf("DoStream returned error: %v", err)
+	}
+	defer func() { _ = stream.Close() }()
+	if stream.ContentLength != -1 {
+		t.Fatalf("expected unknown content length, got %d", stream.ContentLength)
+	}
+	if _, err := io.ReadAll(stream); !errors.Is(err, ErrDownloadTooLarge) {
+		t.Fatalf("expected ErrDownloadTooLarge while reading, got %v", err)
+	}
+}
+
+func TestSaveFileResumesPartialDownloads(t *testing.T) {
+	payload := []byte(strings.Repeat("0123456789", 50))
+	var ranges, ifRanges []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ranges = append(ranges, r.Header.Get("Range"))
+		ifRanges = append(ifRanges, r.Header.Get("If-Range"))
+		w.Header().Set("ETag", `"v2"`)
+		if r.URL.Path == "/no-range" {
+			_, _ = w.Write(payload)
+			return
+		}
+		http.ServeContent(w, r, "label.pdf", time.Time{}, bytes.NewReader(payload))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	sum := sha256.Sum256(payload)
+	want := hex.EncodeToString(sum[:])
+
+	tests := []struct {
+		name        string
+		path        string
+		partial     int
+		validator   string
+		wantRange   string
+		wantResumed bool
+	}{
+		{name: "server honours range", path: "/label.pdf", partial: 120, validator: `"v2"`, wantRange: "bytes=120-", wantResumed: true},
+		{name: "server ignores range", path: "/no-range", partial: 120, validator: `"v2"`, wantRange: "bytes=120-", wantResumed: false},
+		{name: "file changed", path: "/label.pdf", partial: 120, validator: `"v1"`, wantRange: "bytes=120-", wantResumed: false},
+		{name: "no validator", path: "/label.pdf", partial: 120, wantResumed: false},
+		{name: "partial already complete", path: "/label.pdf", partial: len(payload), validator: `"v2"`, wantRange: "bytes=500-", wantResumed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranges, ifRanges = nil, nil
+			target := filepath.Join(t.TempDir(), "out", "label.pdf")
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				t.Fatalf("mkdir: %v", err)
+			}
+			if err := os.WriteFile(target+".partial", payload[:tt.partial], 0o644); err != nil {
+				t.Fatalf("write partial: %v", err)
+			}
+			if tt.validator != "" {
+				if err := os.WriteFile(target+".partial.validator", []byte(tt.validator), 0o644); err != nil {
+					t.Fatalf("write validator: %v", err)
+				}
+			}
+
+			saved, err := SaveFile(target, func(offset int64, validator string) (*Stream, error) {
+				return client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: tt.path}, &StreamOptions{Offset: offset, IfRange: validator})
+			})
+			if err != nil {
+				t.Fatalf("SaveFile returned error: %v", err)
+			}
+			if len(ranges) != 1 || ranges[0] != tt.wantRange {
+				t.Fatalf("unexpected range headers: %v", ranges)
+			}
+			if tt.wantRange != "" && ifRanges[0] != tt.validator {
+				t.Fatalf("unexpected If-Range headers: %v", ifRanges)
+			}
+			if saved.SHA256 != want || saved.Size != int64(len(payload)) || saved.Resumed != tt.wantResumed {
+				t.Fatalf("unexpected saved file: %+v", saved)
+			}
+			written, err := os.ReadFile(target)
+			if err != nil {
+				t.Fatalf("read saved file: %v", err)
+			}
+			if !bytes.Equal(written, payload) {
+				t.Fatalf("unexpected saved content: %q", written)
+			}
+			if _, err := os.Stat(target + ".partial"); !os.IsNotExist(err) {
+				t.Fatalf("expected partial file to be renamed, got %v", err)
+			}
+			if _, err := os.Stat(target + ".partial.validator"); !os.IsNotExist(err) {
+				t.Fatalf("expected validator file to be removed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSaveFileLeavesTargetUntouchedOnFailure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(strings.Repeat("x", 256)))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	target := filepath.Join(t.TempDir(), "manifest.pdf")
+	if err := os.WriteFile(target, []byte("previous"), 0o644); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+
+	_, err := SaveFile(target, func(offset int64, validator string) (*Stream, error) {
+		return client.StreamURL(context.Background(), "shipment.SaveArtifact", server.URL+"/manifest.pdf", &StreamOptions{Offset: offset, MaxSize: 64})
+	})
+	if !errors.Is(err, ErrDownloadTooLarge) {
+		t.Fatalf("expected ErrDownloadTooLarge, got %v", err)
+	}
+	written, _ := os.ReadFile(target)
+	if string(written) != "previous" {
+		t.Fatalf("expected target to be untouched, got %q", written)
+	}
+	if _, err := os.Stat(target + ".partial"); !os.IsNotExist(err) {
+		t.Fatalf("expected oversize partial file to be removed, got %v", err)
+	}
+}
+
 func TestDoRespectsCanceledContext(t *testing.T) {
 	client := New("https://example.com")
 	ctx, cancel := context.WithCancel(context.Background())
@@ -370,6 +585,10 @@ func TestErrorClassificationAndMetadata(t *testing.T) {
 				t.Fatal("expected error, got nil")
 			}
 			tc.assert(t, err)
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Meta.StatusCode != tc.statusCode {
+				t.Fatalf("expected the APIError behind %T, got %v", err, apiErr)
+			}
 		})
 	}
 }
@@ -427,6 +646,431 @@ func TestMiddlewareAndHooksProvideObservabilityIntegration(t *testing.T) {
 	}
 }
 
+type observerFunc func(ctx context.Context, op Operation) (context.Context, func(OperationResult))
+
+func (f observerFunc) StartOperation(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+	return f(ctx, op)
+}
+
+func TestObserversWrapEachOperation(t *testing.T) {
+	type spanKey struct{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/external/courier/track/awb/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"AWB not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	var started []Operation
+	var results []OperationResult
+	client := New(
+		server.URL,
+		WithObservers(observerFunc(func(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+			started = append(started, op)
+			return context.WithValue(ctx, spanKey{}, op.Name), func(result OperationResult) {
+				results = append(results, result)
+			}
+		})),
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				op, ok := OperationFromContext(req.Context())
+				if !ok || op.Attempt != 1 || op.PathTemplate != "/v1/external/courier/track/awb/{awb}" {
+					t.Fatalf("unexpected operation in request context: %+v", op)
+				}
+				if req.Context().Value(spanKey{}) != op.Name {
+					t.Fatal("expected observer context on the outgoing request")
+				}
+				return next.RoundTrip(req)
+			})
+		}),
+	)
+
+	for _, awb := range []string{"141123221084922", "missing"} {
+		_ = client.Do(context.Background(), &Request{
+			Operation:  "shipment.TrackByAWB",
+			Method:     http.MethodGet,
+			Path:       "/v1/external/courier/track/awb/{awb}",
+			PathParams: map[string]string{"awb": awb},
+		}, nil)
+	}
+
+	if len(started) != 2 || started[0].Name != "shipment.TrackByAWB" || started[0].Method != http.MethodGet || started[0].Attempt != 0 {
+		t.Fatalf("unexpected operations: %+v", started)
+	}
+	if len(results) != 2 || results[0].StatusCode != http.StatusOK || results[0].Err != nil || results[0].Attempts != 1 {
+		t.Fatalf("unexpected first result: %+v", results)
+	}
+	var businessErr *BusinessError
+	if results[1].StatusCode != http.StatusNotFound || !errors.As(results[1].Err, &businessErr) {
+		t.Fatalf("unexpected second result: %+v", results[1])
+	}
+}
+
+func TestObserversSeeArtifactFetches(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Fatal("artifact fetch should not send the API token")
+		}
+		_, _ = w.Write([]byte("%PDF-1.4"))
+	}))
+	defer server.Close()
+
+	var started []Operation
+	var results []OperationResult
+	client := New(
+		server.URL,
+		WithToken("secret"),
+		WithObservers(observerFunc(func(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+			started = append(started, op)
+			return ctx, func(result OperationResult) { results = append(results, result) }
+		})),
+		WithMiddleware(func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				if op, ok := OperationFromContext(req.Context()); !ok || op.Name != "shipment.StreamArtifact" || op.PathTemplate != "" || op.Attempt != 1 {
+					t.Fatalf("unexpected operation in request context: %+v", op)
+				}
+				return next.RoundTrip(req)
+			})
+		}),
+	)
+
+	stream, err := client.StreamURL(context.Background(), "shipment.StreamArtifact", server.URL+"/labels/signed.pdf?sig=abc", nil)
+	if err != nil {
+		t.Fatalf("StreamURL: %v", err)
+	}
+	_ = stream.Close()
+	if len(started) != 1 || started[0].Name != "shipment.StreamArtifact" || started[0].Method != http.MethodGet {
+		t.Fatalf("unexpected operations: %+v", started)
+	}
+	if len(results) != 1 || results[0].StatusCode != http.StatusOK || results[0].Attempts != 1 || results[0].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestSlogLogsRedactedRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-42")
+		if r.URL.Path == "/v1/external/orders/show/99" {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Order not found"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"secret-token","customer":{"email":"naruto@example.com","billing_phone":9876543210},"awb":"141123221084922"}`))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	var printed []string
+	client := New(
+		server.URL,
+		WithSlog(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))),
+		WithLogBodies(true),
+		WithLogger(loggerFunc(func(format string, args ...any) {
+			printed = append(printed, fmt.Sprintf(format, args...))
+		})),
+	)
+
+	var response map[string]any
+	if err := client.Do(context.Background(), &Request{
+		Operation: "auth.Login",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/auth/login",
+		Query:     url.Values{"phone": {"9876543210"}},
+		JSONBody:  map[string]string{"email": "naruto@example.com", "password": "hunter2"},
+	}, &response); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if response["awb"] != "141123221084922" {
+		t.Fatalf("expected the logged body to stay readable, got %v", response)
+	}
+	_ = client.Do(context.Background(), &Request{
+		Method:     http.MethodGet,
+		Path:       "/v1/external/orders/show/{id}",
+		PathParams: map[string]string{"id": "99"},
+	}, nil)
+
+	output := logs.String()
+	for _, secret := range []string{"hunter2", "naruto@example.com", "9876543210", "secret-token", "/show/99"} {
+		if strings.Contains(output, secret) || strings.Contains(strings.Join(printed, "\n"), secret) {
+			t.Fatalf("log output leaked %q:\n%s\n%s", secret, output, printed)
+		}
+	}
+
+	var entries []map[string]any
+	decoder := json.NewDecoder(&logs)
+	for decoder.More() {
+		var entry map[string]any
+		if err := decoder.Decode(&entry); err != nil {
+			t.Fatalf("decode log entry: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected request and response entries for both calls, got %d", len(entries))
+	}
+	response1, response2 := entries[1], entries[3]
+	if response1["level"] != "INFO" || response1["operation"] != "auth.Login" || response1["path"] != "/v1/external/auth/login" || response1["status"] != float64(200) || response1["request_id"] != "req-42" {
+		t.Fatalf("unexpected response entry: %v", response1)
+	}
+	if !strings.Contains(response1["body"].(string), "141123221084922") {
+		t.Fatalf("expected non-sensitive fields in the logged body: %v", response1["body"])
+	}
+	if _, ok := response1["latency"]; !ok {
+		t.Fatalf("expected latency attribute: %v", response1)
+	}
+	if response2["level"] != "WARN" || response2["path"] != "/v1/external/orders/show/{id}" {
+		t.Fatalf("unexpected error entry: %v", response2)
+	}
+}
+
+func TestSlogSkipsStreamedBodies(t *testing.T) {
+	payload := strings.Repeat("awb,status\n", 1<<12)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/csv")
+		_, _ = w.Write([]byte(payload))
+	}))
+	defer server.Close()
+
+	var logs bytes.Buffer
+	client := New(server.URL, WithSlog(slog.New(slog.NewJSONHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))), WithLogBodies(true))
+	stream, err := client.DoStream(context.Background(), &Request{Method: http.MethodGet, Path: "/exports/orders.csv"}, nil)
+	if err != nil {
+		t.Fatalf("DoStream returned error: %v", err)
+	}
+	if strings.Contains(logs.String(), `"body"`) {
+		t.Fatalf("streamed body was captured for logging:\n%s", logs.String())
+	}
+	body, err := io.ReadAll(stream)
+	_ = stream.Close()
+	if err != nil || string(body) != payload {
+		t.Fatalf("unexpected streamed body: %d bytes err=%v", len(body), err)
+	}
+}
+
+func TestRedactorMasksSensitiveValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		redactor *Redactor
+		input    string
+		want     string
+	}{
+		{name: "nested fields", redactor: &Redactor{}, input: `{"shipping_address":{"city":"Delhi"},"auth_token":"abc","items":[{"sku":"A1"}]}`, want: `{"auth_token":"[REDACTED]","items":[{"sku":"A1"}],"shipping_address":"[REDACTED]"}`},
+		{name: "free text", redactor: &Redactor{}, input: `call +91 9876543210 or mail a.b@example.in about AWB 141123221084922`, want: `call [REDACTED] or mail [REDACTED] about AWB 141123221084922`},
+		{name: "extra keys", redactor: &Redactor{Keys: []string{"customer_name"}}, input: `{"billing_customer_name":"Naruto","pincode":"110001"}`, want: `{"billing_customer_name":"[REDACTED]","pincode":"110001"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.redactor.JSON([]byte(tt.input))); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	masked := (&Redactor{}).URL(&url.URL{Scheme: "https", Host: "labels.example.com", Path: "/l.pdf", RawQuery: "X-Amz-Signature=abc&page=1"})
+	if masked != "https://labels.example.com/l.pdf?X-Amz-Signature=[REDACTED]&page=1" {
+		t.Fatalf("unexpected masked URL: %s", masked)
+	}
+}
+
+func TestWithResponseMetaCapturesSuccessfulResponses(t *testing.T) {
+	reset := time.Now().Add(time.Minute).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-ok")
+		w.Header().Set("X-RateLimit-Limit", "120")
+		w.Header().Set("X-RateLimit-Remaining", "118")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset, 10))
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL)
+	var meta ResponseMeta
+	ctx := WithResponseMeta(context.Background(), &meta)
+	if err := client.Do(ctx, &Request{Method: http.MethodGet, Path: "/ok"}, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+
+	if meta.StatusCode != http.StatusOK || meta.RequestID != "req-ok" || meta.Method != http.MethodGet {
+		t.Fatalf("unexpected response meta: %+v", meta)
+	}
+	if meta.RateLimit == nil || meta.RateLimit.Limit != 120 || meta.RateLimit.Remaining != 118 || meta.RateLimit.Reset.Unix() != reset {
+		t.Fatalf("unexpected rate limit: %+v", meta.RateLimit)
+	}
+
+	var plain ResponseMeta
+	if err := client.Do(WithResponseMeta(context.Background(), &plain), &Request{Method: http.MethodGet, Path: "/ok"}, nil); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	if plain.Headers.Get("X-Request-Id") != "req-ok" {
+		t.Fatalf("expected headers to be captured: %+v", plain)
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	now := time.Date(2026, 7, 23, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		headers http.Header
+		want    *RateLimit
+	}{
+		{name: "absent", headers: http.Header{}, want: nil},
+		{name: "relative reset", headers: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"30"}}, want: &RateLimit{Reset: now.Add(30 * time.Second)}},
+		{name: "retry after seconds", headers: http.Header{"Retry-After": {"5"}}, want: &RateLimit{RetryAfter: 5 * time.Second, Reset: now.Add(5 * time.Second)}},
+		{name: "retry after date", headers: http.Header{"Retry-After": {now.Add(time.Minute).Format(http.TimeFormat)}}, want: &RateLimit{RetryAfter: time.Minute, Reset: now.Add(time.Minute)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := parseRateLimit(tt.headers, now)
+			if (got == nil) != (tt.want == nil) || (got != nil && (got.Limit != tt.want.Limit || got.Remaining != tt.want.Remaining || got.RetryAfter != tt.want.RetryAfter || !got.Reset.Equal(tt.want.Reset))) {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCallOptionsOverridePerCall(t *testing.T) {
+	var mu sync.Mutex
+	var attempts map[string]int
+	var lastHeaders http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		attempts[r.Method+" "+r.URL.Path]++
+		attempt := attempts[r.Method+" "+r.URL.Path]
+		lastHeaders = r.Header.Clone()
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		case "/flaky":
+			if attempt < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+		w.Header().Set("X-Request-Id", "req-call")
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	client := New(server.URL, WithRetry(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		opts         []CallOption
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "get is retried", method: http.MethodGet, path: "/flaky", wantAttempts: 3},
+		{name: "post is not retried", method: http.MethodPost, path: "/flaky", wantAttempts: 1, wantErr: true},
+		{name: "post with idempotency key is retried", method: http.MethodPost, path: "/flaky", opts: []CallOption{WithIdempotencyKey("order-224-447")}, wantAttempts: 3},
+		{name: "retries disabled for one call", method: http.MethodGet, path: "/flaky", opts: []CallOption{WithRetryPolicy(NoRetries)}, wantAttempts: 1, wantErr: true},
+		{name: "call timeout", method: http.MethodGet, path: "/slow", opts: []CallOption{WithCallTimeout(20 * time.Millisecond), WithRetryPolicy(NoRetries)}, wantAttempts: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mu.Lock()
+			attempts = map[string]int{}
+			mu.Unlock()
+			err := client.Do(context.Background(), &Request{Method: tt.method, Path: tt.path}, nil, tt.opts...)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			mu.Lock()
+			got := attempts[tt.method+" "+tt.path]
+			mu.Unlock()
+			if got != tt.wantAttempts {
+				t.Fatalf("expected %d attempts, got %d", tt.wantAttempts, got)
+			}
+		})
+	}
+	var meta ResponseMeta
+	request := &Request{Method: http.MethodGet, Path: "/ok"}
+	if err := client.Do(context.Background(), request, nil, WithHeader("X-Channel", "web"), CaptureResponseMeta(&meta)); err != nil {
+		t.Fatalf("Do returned error: %v", err)
+	}
+	mu.Lock()
+	headers := lastHeaders
+	mu.Unlock()
+	if headers.Get(IdempotencyKeyHeader) != "" {
+		t.Fatal("idempotency key leaked into a later call")
+	}
+	if headers.Get("X-Channel") != "web" || meta.RequestID != "req-call" {
+		t.Fatalf("unexpected headers %v or meta %+v", headers, meta)
+	}
+	if request.Headers != nil || request.ResponseMeta != nil {
+		t.Fatal("call options must not modify the caller's request")
+	}
+
+	resp, err := client.DoRaw(context.Background(), &Request{Method: http.MethodGet, Path: "/ok"}, WithCallTimeout(time.Second))
+	if err != nil {
+		t.Fatalf("DoRaw returned error: %v", err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil || string(body) != `{"ok":true}` {
+		t.Fatalf("expected the body to outlive DoRaw: %q %v", body, err)
+	}
+}
+
+func TestRetryPolicyHonoursRetryAfter(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 2, InitialBackoff: time.Millisecond, MaxBackoff: 4 * time.Millisecond}
+	limited := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{"Retry-After": {"2"}}}
+	if delay := (&RetryPolicy{MaxAttempts: 2}).backoff(1, limited); delay != 2*time.Second {
+		t.Fatalf("expected Retry-After delay, got %s", delay)
+	}
+	if delay := policy.backoff(1, limited); delay != 4*time.Millisecond {
+		t.Fatalf("expected Retry-After to be capped at MaxBackoff, got %s", delay)
+	}
+	for attempt := 1; attempt < 10; attempt++ {
+		if delay := policy.backoff(attempt, nil); delay <= 0 || delay > 4*time.Millisecond {
+			t.Fatalf("attempt %d: delay %s outside the backoff bounds", attempt, delay)
+		}
+	}
+	upload := &Request{Method: http.MethodPut, Path: "/upload", RawBody: strings.NewReader("csv")}
+	if policy.shouldRetry(1, upload, &http.Response{StatusCode: http.StatusBadGateway}, nil) {
+		t.Fatal("requests with a consumed body must not be retried")
+	}
+}
+
+func TestRetryPolicySkipsRequestsThatWereNotSent(t *testing.T) {
+	logins := 0
+	source := tokenSourceFunc(func(context.Context) (string, error) {
+		logins++
+		return "", &AuthError{APIError: &APIError{Message: "invalid credentials", Meta: ResponseMeta{StatusCode: http.StatusUnauthorized}}}
+	})
+	client := New("https://example.com", WithTokenSource(source), WithRetry(&RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}))
+
+	err := client.Do(context.Background(), &Request{Method: http.MethodGet, Path: "/orders"}, nil)
+	var authErr *AuthError
+	if !errors.As(err, &authErr) {
+		t.Fatalf("expected AuthError, got %v", err)
+	}
+	if logins != 1 {
+		t.Fatalf("expected one login attempt, got %d", logins)
+	}
+}
+
+type tokenSourceFunc func(context.Context) (string, error)
+
+func (f tokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+type loggerFunc func(format string, args ...any)
+
+func (f loggerFunc) Printf(format string, args ...any) {
+	f(format, args...)
+}
+
 type roundTripperFunc func(*http.Request) (*http.Response, error)
 
 func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 type ResponseMeta struct {
@@ -15,6 +16,7 @@ type ResponseMeta struct {
 	RequestID  string      `json:"request_id,omitempty"`
 	Method     string      `json:"method,omitempty"`
 	URL        string      `json:"url,omitempty"`
+	RateLimit  *RateLimit  `json:"rate_limit,omitempty"`
 }
 
 type APIError struct {
@@ -48,6 +50,20 @@ func (e *TransportError) Unwrap() error {
 	return e.Err
 }
 
+// CircuitOpenError is returned without sending the request while a circuit
+// breaker is open for the request's endpoint group.
+type CircuitOpenError struct {
+	Group      string
+	RetryAfter time.Duration
+}
+
+func (e *CircuitOpenError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("shiprocket circuit open: group=%s retry_after=%s", e.Group, e.RetryAfter)
+	}
+	return fmt.Sprintf("shiprocket circuit open: group=%s", e.Group)
+}
+
 type AuthError struct{ *APIError }
 type RateLimitError struct {
 	*APIError
@@ -57,6 +73,13 @@ type ValidationError struct{ *APIError }
 type BusinessError struct{ *APIError }
 type ServerError struct{ *APIError }
 
+// Unwrap lets errors.As find the APIError of any classified error.
+func (e *AuthError) Unwrap() error       { return e.APIError }
+func (e *RateLimitError) Unwrap() error  { return e.APIError }
+func (e *ValidationError) Unwrap() error { return e.APIError }
+func (e *BusinessError) Unwrap() error   { return e.APIError }
+func (e *ServerError) Unwrap() error     { return e.APIError }
+
 func newAPIError(resp *http.Response) error {
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
@@ -129,12 +152,17 @@ func responseMeta(resp *http.Response) ResponseMeta {
 		}
 	}
 
+	meta.RequestID = requestID(resp.Header)
+	meta.RateLimit = parseRateLimit(resp.Header, time.Now())
+
+	return meta
+}
+
+func requestID(headers http.Header) string {
 	for _, key := range []string{"X-Request-Id", "X-Request-ID", "X-Correlation-Id", "X-Correlation-ID"} {
-		if requestID := resp.Header.Get(key); requestID != "" {
-			meta.RequestID = requestID
-			break
+		if requestID := headers.Get(key); requestID != "" {
+			return requestID
 		}
 	}
-
-	return meta
+	return ""
 }
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// maxLoggedBody caps request and response bodies logged at debug level.
+const maxLoggedBody = 4 << 10
+
+func (c *Client) redactor() *Redactor {
+	if c.Redactor != nil {
+		return c.Redactor
+	}
+	return &Redactor{}
+}
+
+func (c *Client) logRequest(httpReq *http.Request, req *Request) {
+	ctx := httpReq.Context()
+	if c.Logger != nil {
+		target := c.redactor().URL(httpReq.URL)
+		if op, ok := OperationFromContext(ctx); ok && op.PathTemplate != "" {
+			target = op.PathTemplate
+		}
+		c.Logger.Printf("shiprocket request %s %s", httpReq.Method, target)
+	}
+	if c.Slog == nil || !c.Slog.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := requestAttrs(httpReq)
+	if c.LogBodies && req != nil && req.JSONBody != nil {
+		if body, err := json.Marshal(req.JSONBody); err == nil {
+			attrs = append(attrs, slog.String("body", truncateLogBody(c.redactor().JSON(body))))
+		}
+	}
+	c.Slog.LogAttrs(ctx, slog.LevelDebug, "shiprocket request", attrs...)
+}
+
+// logResponse logs the outcome of a request. With captureBody, text bodies are
+// buffered and logged when LogBodies is set and debug logging is enabled;
+// streamed downloads pass false so their bodies are never read here.
+func (c *Client) logResponse(httpReq *http.Request, resp *http.Response, err error, latency time.Duration, captureBody bool) {
+	if c.Slog == nil {
+		return
+	}
+	ctx := httpReq.Context()
+
+	level := slog.LevelInfo
+	switch {
+	case err != nil || resp.StatusCode >= 500:
+		level = slog.LevelError
+	case resp.StatusCode >= 400:
+		level = slog.LevelWarn
+	}
+	if !c.Slog.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := requestAttrs(httpReq)
+	attrs = append(attrs, slog.Duration("latency", latency))
+	if err != nil {
+		attrs = append(attrs, slog.String("error", c.redactor().String(err.Error())))
+	} else {
+		attrs = append(attrs, slog.Int("status", resp.StatusCode))
+		if id := requestID(resp.Header); id != "" {
+			attrs = append(attrs, slog.String("request_id", id))
+		}
+		if captureBody && c.LogBodies && c.Slog.Enabled(ctx, slog.LevelDebug) && isTextContent(resp.Header.Get("Content-Type")) {
+			if body, readErr := bufferBody(resp); readErr == nil {
+				attrs = append(attrs, slog.String("body", truncateLogBody(c.redactor().JSON(body))))
+			}
+		}
+	}
+	c.Slog.LogAttrs(ctx, level, "shiprocket response", attrs...)
+}
+
+// requestAttrs uses the path template rather than the URL so AWBs, order IDs
+// and query values stay out of the logs. Artifact fetches have no template
+// and log the host instead.
+func requestAttrs(httpReq *http.Request) []slog.Attr {
+	attrs := []slog.Attr{slog.String("method", httpReq.Method)}
+	if op, ok := OperationFromContext(httpReq.Context()); ok {
+		if op.PathTemplate != "" {
+			attrs = append(attrs, slog.String("path", op.PathTemplate))
+		} else {
+			attrs = append(attrs, slog.String("host", httpReq.URL.Host))
+		}
+		if op.Name != "" {
+			attrs = append(attrs, slog.String("operation", op.Name))
+		}
+		if op.Attempt > 1 {
+			attrs = append(attrs, slog.Int("attempt", op.Attempt))
+		}
+	} else {
+		attrs = append(attrs, slog.String("host", httpReq.URL.Host))
+	}
+	return attrs
+}
+
+// bufferBody reads the response body for logging and replaces it so the
+// caller can still decode it.
+func bufferBody(resp *http.Response) ([]byte, error) {
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return body, err
+}
+
+func isTextContent(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "json") || strings.HasPrefix(contentType, "text/")
+}
+
+func truncateLogBody(body []byte) string {
+	if len(body) > maxLoggedBody {
+		return string(body[:maxLoggedBody]) + "...(truncated)"
+	}
+	return string(body)
+}
@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RateLimit holds the rate-limit headers of a response. Fields are zero when
+// the matching header is absent.
+type RateLimit struct {
+	Limit      int           `json:"limit,omitempty"`
+	Remaining  int           `json:"remaining"`
+	Reset      time.Time     `json:"reset"`
+	RetryAfter time.Duration `json:"retry_after,omitempty"`
+}
+
+type responseMetaContextKey struct{}
+
+// WithResponseMeta returns a context that makes the SDK copy the metadata of
+// the final response into meta, whether the call succeeds or fails.
+func WithResponseMeta(ctx context.Context, meta *ResponseMeta) context.Context {
+	return context.WithValue(ctx, responseMetaContextKey{}, meta)
+}
+
+func captureResponseMeta(ctx context.Context, resp *http.Response) {
+	if meta, ok := ctx.Value(responseMetaContextKey{}).(*ResponseMeta); ok && meta != nil {
+		*meta = responseMeta(resp)
+	}
+}
+
+func parseRateLimit(headers http.Header, now time.Time) *RateLimit {
+	limit, hasLimit := headerInt(headers, "X-RateLimit-Limit")
+	remaining, hasRemaining := headerInt(headers, "X-RateLimit-Remaining")
+	reset, hasReset := headerInt(headers, "X-RateLimit-Reset")
+	retryAfter, hasRetryAfter := parseRetryAfter(headers.Get("Retry-After"), now)
+	if !hasLimit && !hasRemaining && !hasReset && !hasRetryAfter {
+		return nil
+	}
+
+	rateLimit := &RateLimit{Limit: limit, Remaining: remaining, RetryAfter: retryAfter}
+	switch {
+	case hasReset && reset > 1_000_000_000:
+		rateLimit.Reset = time.Unix(int64(reset), 0)
+	case hasReset:
+		rateLimit.Reset = now.Add(time.Duration(reset) * time.Second)
+	case hasRetryAfter:
+		rateLimit.Reset = now.Add(retryAfter)
+	}
+	return rateLimit
+}
+
+func headerInt(headers http.Header, key string) (int, bool) {
+	value := strings.TrimSpace(headers.Get(key))
+	if value == "" {
+		return 0, false
+	}
+	parsed, err := strconv.Atoi(value)
+	return parsed, err == nil
+}
+
+// parseRetryAfter accepts both forms allowed by RFC 9110: delay seconds and
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now), 0), true
+	}
+	return 0, false
+}
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Operation identifies the SDK call an HTTP request belongs to. Name has the
+// form "<package>.<Method>", for example "shipment.TrackByAWB", and
+// PathTemplate is the request path before path parameters are substituted.
+// Tenant is set for clients built by a pool.
+type Operation struct {
+	Name         string
+	Method       string
+	PathTemplate string
+	Tenant       string
+	Attempt      int
+}
+
+type OperationResult struct {
+	StatusCode int
+	Attempts   int
+	Err        error
+}
+
+// Observer is notified once per SDK call, around every HTTP attempt made for
+// it. The returned context is used for the outgoing requests, so a tracing
+// observer can attach its span there. The returned function may be nil.
+type Observer interface {
+	StartOperation(ctx context.Context, op Operation) (context.Context, func(OperationResult))
+}
+
+type operationContextKey struct{}
+
+// OperationFromContext returns the operation of a request issued by the SDK.
+// Middleware and Hook.Before read it from req.Context(). Attempt starts at 1
+// and is only set on the contexts of outgoing HTTP requests.
+func OperationFromContext(ctx context.Context) (Operation, bool) {
+	if ctx == nil {
+		return Operation{}, false
+	}
+	op, ok := ctx.Value(operationContextKey{}).(Operation)
+	return op, ok
+}
+
+// OperationFromResponse returns the operation a response belongs to, for use
+// in Hook.After. It reports false for a nil response.
+func OperationFromResponse(resp *http.Response) (Operation, bool) {
+	if resp == nil || resp.Request == nil {
+		return Operation{}, false
+	}
+	return OperationFromContext(resp.Request.Context())
+}
+
+func withOperation(ctx context.Context, op Operation) context.Context {
+	return context.WithValue(ctx, operationContextKey{}, op)
+}
+
+type tenantContextKey struct{}
+
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantContextKey{}, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) (string, bool) {
+	if ctx == nil {
+		return "", false
+	}
+	tenantID, ok := ctx.Value(tenantContextKey{}).(string)
+	return tenantID, ok
+}
+
+type operationRun struct {
+	op       Operation
+	attempts int
+	finish   []func(OperationResult)
+	// streamed runs hand the response body to the caller unread, so it is
+	// never captured for logging.
+	streamed bool
+}
+
+func (c *Client) startOperation(ctx context.Context, req *Request) (context.Context, *operationRun) {
+	run := &operationRun{}
+	if req == nil {
+		return ctx, run
+	}
+
+	run.op = Operation{
+		Name:         req.Operation,
+		Method:       strings.ToUpper(strings.TrimSpace(req.Method)),
+		PathTemplate: req.Path,
+		Tenant:       c.Tenant,
+	}
+	if c.Tenant != "" {
+		ctx = WithTenant(ctx, c.Tenant)
+	}
+	ctx = withOperation(ctx, run.op)
+	for _, observer := range c.Observers {
+		var finish func(OperationResult)
+		ctx, finish = observer.StartOperation(ctx, run.op)
+		if finish != nil {
+			run.finish = append(run.finish, finish)
+		}
+	}
+
+	return ctx, run
+}
+
+func (r *operationRun) attempt(ctx context.Context) context.Context {
+	r.attempts++
+	op := r.op
+	op.Attempt = r.attempts
+	return withOperation(ctx, op)
+}
+
+func (r *operationRun) end(resp *http.Response, err error) {
+	result := OperationResult{Attempts: r.attempts, Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	for i := len(r.finish) - 1; i >= 0; i-- {
+		r.finish[i](result)
+	}
+}
@@ -0,0 +1,137 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+const redacted = "[REDACTED]"
+
+var defaultRedactKeys = []string{
+	"token",
+	"password",
+	"secret",
+	"authorization",
+	"apikey",
+	"signature",
+	"credential",
+	"email",
+	"phone",
+	"mobile",
+	"address",
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	phonePattern = regexp.MustCompile(`(?:\+?91[\s\-]?)?\b[6-9]\d{9}\b`)
+)
+
+// Redactor masks secrets and personal data before they are logged. Values of
+// JSON fields and query parameters whose names contain a sensitive word are
+// replaced, and email addresses and Indian mobile numbers are masked in any
+// remaining text. The zero value uses the default word list.
+type Redactor struct {
+	// Keys adds words to the default list. Matching ignores case, "_" and "-".
+	Keys []string
+}
+
+func (r *Redactor) sensitive(key string) bool {
+	normalized := normalizeRedactKey(key)
+	for _, word := range defaultRedactKeys {
+		if strings.Contains(normalized, word) {
+			return true
+		}
+	}
+	if r != nil {
+		for _, word := range r.Keys {
+			if word := normalizeRedactKey(word); word != "" && strings.Contains(normalized, word) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func normalizeRedactKey(key string) string {
+	key = strings.ToLower(key)
+	key = strings.ReplaceAll(key, "_", "")
+	return strings.ReplaceAll(key, "-", "")
+}
+
+// String masks email addresses and phone numbers in free text.
+func (r *Redactor) String(value string) string {
+	value = emailPattern.ReplaceAllString(value, redacted)
+	return phonePattern.ReplaceAllString(value, redacted)
+}
+
+// JSON masks sensitive fields in a JSON document. Bodies that are not valid
+// JSON are treated as text.
+func (r *Redactor) JSON(body []byte) []byte {
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return []byte(r.String(string(body)))
+	}
+
+	masked, err := json.Marshal(r.value(value))
+	if err != nil {
+		return []byte(r.String(string(body)))
+	}
+	return masked
+}
+
+func (r *Redactor) value(value any) any {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, field := range typed {
+			if r.sensitive(key) && field != nil {
+				typed[key] = redacted
+				continue
+			}
+			typed[key] = r.value(field)
+		}
+		return typed
+	case []any:
+		for i, item := range typed {
+			typed[i] = r.value(item)
+		}
+		return typed
+	case string:
+		return r.String(typed)
+	default:
+		return value
+	}
+}
+
+func (r *Redactor) Query(values url.Values) url.Values {
+	masked := make(url.Values, len(values))
+	for key, items := range values {
+		for _, item := range items {
+			if r.sensitive(key) {
+				item = redacted
+			} else {
+				item = r.String(item)
+			}
+			masked.Add(key, item)
+		}
+	}
+	return masked
+}
+
+// URL returns u with sensitive query parameters masked.
+func (r *Redactor) URL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return r.String(u.String())
+	}
+
+	masked := *u
+	masked.RawQuery = strings.ReplaceAll(r.Query(u.Query()).Encode(), url.QueryEscape(redacted), redacted)
+	return r.String(masked.String())
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 500 * time.Millisecond
+	defaultRetryMaxBackoff     = 10 * time.Second
+)
+
+// RetryPolicy retries failed attempts with exponential backoff and jitter.
+// A 429 response waits for its Retry-After delay instead when one is given,
+// capped at MaxBackoff.
+type RetryPolicy struct {
+	// MaxAttempts counts the first attempt. Values below 2 disable retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Retryable reports whether an attempt should be retried. The default
+	// retries transport errors, 429 and 5xx responses, but only for requests
+	// that are safe to repeat: GET, HEAD, PUT, DELETE and requests carrying
+	// an idempotency key. Requests that fail before they are sent, such as
+	// an invalid path or a failed token login, are never retried.
+	Retryable func(req *Request, resp *http.Response, err error) bool
+}
+
+// NoRetries disables retries for a call when passed to WithRetryPolicy.
+var NoRetries = &RetryPolicy{MaxAttempts: 1}
+
+func (p *RetryPolicy) shouldRetry(attempt int, req *Request, resp *http.Response, err error) bool {
+	if p == nil || attempt >= p.MaxAttempts || !replayableBody(req) {
+		return false
+	}
+	var buildErr *buildError
+	if errors.As(err, &buildErr) {
+		return false
+	}
+	if p.Retryable != nil {
+		return p.Retryable(req, resp, err)
+	}
+	return DefaultRetryable(req, resp, err)
+}
+
+func DefaultRetryable(req *Request, resp *http.Response, err error) bool {
+	if !idempotent(req) {
+		return false
+	}
+	if err != nil {
+		var circuitErr *CircuitOpenError
+		var authErr *AuthError
+		return !errors.As(err, &circuitErr) && !errors.As(err, &authErr)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+func idempotent(req *Request) bool {
+	if req.IdempotencyKey != "" {
+		return true
+	}
+	switch strings.ToUpper(req.Method) {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
+
+// buildError marks a request that could not be built, so retrying it would
+// fail the same way.
+type buildError struct {
+	err error
+}
+
+func (e *buildError) Error() string {
+	return e.err.Error()
+}
+
+func (e *buildError) Unwrap() error {
+	return e.err
+}
+
+// replayableBody reports whether the body can be rebuilt for another attempt.
+// Raw readers and multipart files are consumed by the first attempt.
+func replayableBody(req *Request) bool {
+	return req.RawBody == nil && (req.Multipart == nil || len(req.Multipart.Files) == 0)
+}
+
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultRetryMaxBackoff
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if delay, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+			return min(delay, maxBackoff)
+		}
+	}
+
+	initial := p.InitialBackoff
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+
+	delay := initial << (attempt - 1)
+	if delay <= 0 || delay > maxBackoff {
+		delay = maxBackoff
+	}
+	// Full jitter keeps concurrent callers from retrying in lockstep.
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func discardBody(resp *http.Response) {
+	if resp != nil && resp.Body != nil {
+		_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+		_ = resp.Body.Close()
+	}
+}
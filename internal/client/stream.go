@@ -0,0 +1,395 @@
+package client
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrDownloadTooLarge = errors.New("shiprocket download exceeds the maximum size")
+
+type StreamOptions struct {
+	// MaxSize caps the total size of the file in bytes, including any
+	// already downloaded prefix when resuming. Zero means no limit.
+	MaxSize int64
+	// Offset requests the file from this byte offset with a Range header.
+	Offset int64
+	// Length limits the request to this many bytes from Offset. Zero reads
+	// to the end of the file.
+	Length int64
+	// IfRange is sent as If-Range with a ranged request, so the server
+	// returns the whole file instead of a range when it has changed.
+	IfRange string
+}
+
+// Stream is a download body that is read incrementally. The SHA-256 of the
+// bytes read so far is computed as the caller consumes the body.
+type Stream struct {
+	StatusCode    int
+	Headers       http.Header
+	ContentType   string
+	FileName      string
+	ContentLength int64
+	Offset        int64
+	AcceptsRanges bool
+	// Validator is the strong ETag, or failing that the Last-Modified date,
+	// to send as If-Range when resuming this download.
+	Validator string
+
+	body    io.ReadCloser
+	hash    hash.Hash
+	read    int64
+	maxSize int64
+}
+
+func (s *Stream) Read(p []byte) (int, error) {
+	n, err := s.body.Read(p)
+	if n > 0 {
+		s.hash.Write(p[:n])
+		s.read += int64(n)
+		if s.maxSize > 0 && s.Offset+s.read > s.maxSize {
+			return n, ErrDownloadTooLarge
+		}
+	}
+	return n, err
+}
+
+func (s *Stream) Close() error {
+	return s.body.Close()
+}
+
+func (s *Stream) BytesRead() int64 {
+	return s.read
+}
+
+// SHA256 returns the hex encoded digest of the bytes read from this response.
+func (s *Stream) SHA256() string {
+	return hex.EncodeToString(s.hash.Sum(nil))
+}
+
+type SavedFile struct {
+	Path        string
+	Size        int64
+	SHA256      string
+	ContentType string
+	FileName    string
+	Resumed     bool
+}
+
+func (c *Client) DoStream(ctx context.Context, req *Request, opts *StreamOptions, callOpts ...CallOption) (*Stream, error) {
+	req = applyCallOptions(req, callOpts)
+	options := streamOptions(opts)
+	if rangeHeader := options.rangeHeader(); rangeHeader != "" {
+		streamReq := *req
+		streamReq.Headers = req.Headers.Clone()
+		if streamReq.Headers == nil {
+			streamReq.Headers = http.Header{}
+		}
+		streamReq.Headers.Set("Range", rangeHeader)
+		if options.IfRange != "" {
+			streamReq.Headers.Set("If-Range", options.IfRange)
+		}
+		req = &streamReq
+	}
+
+	ctx, cancel := callContext(ctx, req)
+	ctx, run := c.startOperation(ctx, req)
+	run.streamed = true
+	resp, err := c.send(ctx, run, req)
+	if err != nil {
+		cancel()
+		run.end(nil, err)
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	stream, err := newStream(resp, options, req.ExpectedCode)
+	run.end(resp, err)
+	return stream, err
+}
+
+// StreamURL fetches an absolute artifact URL, such as a label or manifest
+// link, without sending the API bearer token. operation names the SDK
+// method for observers; artifact operations have no path template, since
+// the URL is signed and unique per file. Call options set the timeout,
+// extra headers and response metadata; artifact fetches are not retried.
+// Client middleware wraps these fetches like API requests, so middleware
+// that should only see API traffic can skip requests whose Operation has an
+// empty PathTemplate.
+func (c *Client) StreamURL(ctx context.Context, operation string, rawURL string, opts *StreamOptions, callOpts ...CallOption) (*Stream, error) {
+	options := streamOptions(opts)
+	call := applyCallOptions(&Request{Operation: operation, Method: http.MethodGet}, callOpts)
+	ctx, cancel := callContext(ctx, call)
+	ctx, run := c.startOperation(ctx, call)
+	run.streamed = true
+	httpReq, err := http.NewRequestWithContext(run.attempt(ctx), http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		run.end(nil, err)
+		return nil, err
+	}
+	for key, values := range call.Headers {
+		httpReq.Header[key] = values
+	}
+	if c.UserAgent != "" {
+		httpReq.Header.Set("User-Agent", c.UserAgent)
+	}
+	if rangeHeader := options.rangeHeader(); rangeHeader != "" {
+		httpReq.Header.Set("Range", rangeHeader)
+		if options.IfRange != "" {
+			httpReq.Header.Set("If-Range", options.IfRange)
+		}
+	}
+
+	for _, hook := range c.Hooks {
+		hook.Before(httpReq)
+	}
+
+	c.logRequest(httpReq, nil)
+	started := time.Now()
+
+	resp, err := c.httpClient().Do(httpReq)
+
+	for _, hook := range c.Hooks {
+		hook.After(resp, err)
+	}
+	c.logResponse(httpReq, resp, err, time.Since(started), false)
+	if resp != nil {
+		captureResponseMeta(ctx, resp)
+		if call.ResponseMeta != nil {
+			*call.ResponseMeta = responseMeta(resp)
+		}
+	}
+
+	if err != nil {
+		cancel()
+		var circuitErr *CircuitOpenError
+		if errors.As(err, &circuitErr) {
+			run.end(nil, circuitErr)
+			return nil, circuitErr
+		}
+		err = &TransportError{
+			Err:    err,
+			Method: httpReq.Method,
+			URL:    httpReq.URL.String(),
+		}
+		run.end(nil, err)
+		return nil, err
+	}
+	resp.Body = cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+
+	stream, err := newStream(resp, options, nil)
+	run.end(resp, err)
+	return stream, err
+}
+
+func streamOptions(opts *StreamOptions) StreamOptions {
+	if opts == nil {
+		return StreamOptions{}
+	}
+	return *opts
+}
+
+func (o StreamOptions) rangeHeader() string {
+	switch {
+	case o.Length > 0:
+		return fmt.Sprintf("bytes=%d-%d", o.Offset, o.Offset+o.Length-1)
+	case o.Offset > 0:
+		return fmt.Sprintf("bytes=%d-", o.Offset)
+	}
+	return ""
+}
+
+func newStream(resp *http.Response, options StreamOptions, expectedCodes []int) (*Stream, error) {
+	offset := int64(0)
+	switch {
+	case options.Offset > 0 && resp.StatusCode == http.StatusPartialContent:
+		start, ok := contentRangeStart(resp.Header.Get("Content-Range"))
+		if !ok || start != options.Offset {
+			_ = resp.Body.Close()
+			return nil, fmt.Errorf("shiprocket download: unexpected content range %q for offset %d", resp.Header.Get("Content-Range"), options.Offset)
+		}
+		offset = start
+	case options.Offset > 0 && resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file already holds every byte when the server reports
+		// a total size equal to the offset.
+		_ = resp.Body.Close()
+		size, ok := contentRangeSize(resp.Header.Get("Content-Range"))
+		if !ok || size != options.Offset {
+			return nil, newAPIError(resp)
+		}
+		return &Stream{
+			StatusCode:    resp.StatusCode,
+			Headers:       resp.Header.Clone(),
+			Offset:        size,
+			AcceptsRanges: true,
+			Validator:     options.IfRange,
+			body:          http.NoBody,
+			hash:          sha256.New(),
+			maxSize:       options.MaxSize,
+		}, nil
+	case !isExpectedStatus(resp.StatusCode, expectedCodes):
+		defer func() { _ = resp.Body.Close() }()
+		return nil, newAPIError(resp)
+	}
+
+	if options.MaxSize > 0 && resp.ContentLength > 0 && offset+resp.ContentLength > options.MaxSize {
+		_ = resp.Body.Close()
+		return nil, ErrDownloadTooLarge
+	}
+
+	return &Stream{
+		StatusCode:    resp.StatusCode,
+		Headers:       resp.Header.Clone(),
+		ContentType:   resp.Header.Get("Content-Type"),
+		FileName:      downloadFileName(resp),
+		ContentLength: resp.ContentLength,
+		Offset:        offset,
+		AcceptsRanges: strings.EqualFold(resp.Header.Get("Accept-Ranges"), "bytes") || offset > 0,
+		Validator:     responseValidator(resp.Header),
+		body:          resp.Body,
+		hash:          sha256.New(),
+		maxSize:       options.MaxSize,
+	}, nil
+}
+
+func contentRangeStart(contentRange string) (int64, bool) {
+	value, ok := strings.CutPrefix(strings.TrimSpace(contentRange), "bytes ")
+	if !ok {
+		return 0, false
+	}
+	start, _, ok := strings.Cut(value, "-")
+	if !ok {
+		return 0, false
+	}
+	offset, err := strconv.ParseInt(strings.TrimSpace(start), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return offset, true
+}
+
+// contentRangeSize reads the complete length from a Content-Range header
+// such as "bytes */1234".
+func contentRangeSize(contentRange string) (int64, bool) {
+	_, size, ok := strings.Cut(strings.TrimSpace(contentRange), "/")
+	if !ok {
+		return 0, false
+	}
+	length, err := strconv.ParseInt(strings.TrimSpace(size), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return length, true
+}
+
+// responseValidator picks the If-Range value for resuming a response. Weak
+// ETags cannot be used with If-Range.
+func responseValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// SaveFile downloads into path atomically. Bytes are written to path plus a
+// ".partial" suffix and renamed into place once the body has been fully read.
+// The response's validator is kept next to the partial file, and a partial
+// file left by an earlier failed attempt is resumed with a Range request
+// carrying it as If-Range. The download starts over when there is no
+// validator, or when the server ignores the range because the file changed.
+// The returned checksum always covers the complete file.
+func SaveFile(path string, open func(offset int64, validator string) (*Stream, error)) (*SavedFile, error) {
+	partial := path + ".partial"
+	validatorPath := partial + ".validator"
+
+	offset := int64(0)
+	digest := sha256.New()
+	validator := ""
+	if saved, err := os.ReadFile(validatorPath); err == nil {
+		validator = strings.TrimSpace(string(saved))
+	}
+	if existing, err := os.Open(partial); err == nil {
+		if validator != "" {
+			offset, err = io.Copy(digest, existing)
+		}
+		_ = existing.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stream, err := open(offset, validator)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	if stream.Offset != offset {
+		offset = stream.Offset
+		digest.Reset()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	if stream.Validator != "" {
+		if err := os.WriteFile(validatorPath, []byte(stream.Validator), 0o644); err != nil {
+			return nil, err
+		}
+	} else {
+		_ = os.Remove(validatorPath)
+	}
+	file, err := os.OpenFile(partial, os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Truncate(offset); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if _, err := file.Seek(offset, io.SeekStart); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	written, err := io.Copy(io.MultiWriter(file, digest), stream)
+	if err != nil {
+		_ = file.Close()
+		if errors.Is(err, ErrDownloadTooLarge) {
+			_ = os.Remove(partial)
+			_ = os.Remove(validatorPath)
+		}
+		return nil, err
+	}
+	if err := file.Sync(); err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	if err := file.Close(); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(partial, path); err != nil {
+		return nil, err
+	}
+	_ = os.Remove(validatorPath)
+
+	return &SavedFile{
+		Path:        path,
+		Size:        offset + written,
+		SHA256:      hex.EncodeToString(digest.Sum(nil)),
+		ContentType: stream.ContentType,
+		FileName:    stream.FileName,
+		Resumed:     offset > 0,
+	}, nil
+}
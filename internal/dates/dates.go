@@ -0,0 +1,38 @@
+// Package dates parses the timestamps Shiprocket returns across endpoints.
+package dates
+
+import (
+	"strings"
+	"time"
+)
+
+// IST is used for timestamps without a zone; Shiprocket reports them in
+// Indian Standard Time.
+var IST = time.FixedZone("IST", 5*60*60+30*60)
+
+var layouts = []string{
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.RFC3339,
+	"2006-01-02",
+	"02 Jan 2006, 03:04 PM",
+	"02 Jan 2006 03:04 PM",
+	"02 Jan 2006 15:04:05",
+	"02 Jan 2006",
+	"Jan 02, 2006",
+	"02-01-2006 15:04:05",
+	"02-01-2006 15:04",
+	"02-01-2006",
+}
+
+// Parse reads value in any of the layouts Shiprocket uses, in IST unless
+// the value carries its own offset.
+func Parse(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	for _, layout := range layouts {
+		if parsed, err := time.ParseInLocation(layout, value, IST); err == nil {
+			return parsed, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,31 @@
+package dates
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{value: "2024-01-02 15:04:05", want: time.Date(2024, 1, 2, 15, 4, 5, 0, IST)},
+		{value: " 2024-01-02 15:04 ", want: time.Date(2024, 1, 2, 15, 4, 0, 0, IST)},
+		{value: "2024-01-02", want: time.Date(2024, 1, 2, 0, 0, 0, 0, IST)},
+		{value: "2024-01-02T10:00:00Z", want: time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)},
+		{value: "31 Jul 2019, 12:37 PM", want: time.Date(2019, 7, 31, 12, 37, 0, 0, IST)},
+		{value: "21 Sep 2022 05:28 PM", want: time.Date(2022, 9, 21, 17, 28, 0, 0, IST)},
+		{value: "Jan 02, 2024", want: time.Date(2024, 1, 2, 0, 0, 0, 0, IST)},
+		{value: "02-01-2024 09:30", want: time.Date(2024, 1, 2, 9, 30, 0, 0, IST)},
+	}
+	for _, tt := range tests {
+		got, ok := Parse(tt.value)
+		if !ok || !got.Equal(tt.want) {
+			t.Fatalf("Parse(%q) = %v, %v; want %v", tt.value, got, ok, tt.want)
+		}
+	}
+	if _, ok := Parse("yesterday"); ok {
+		t.Fatal("expected an unknown layout to fail")
+	}
+}
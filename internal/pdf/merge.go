@@ -0,0 +1,328 @@
+package pdf
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+const (
+	catalogObject = 1
+	pagesObject   = 2
+)
+
+var inheritedPageKeys = []Name{"Resources", "MediaBox", "CropBox", "Rotate"}
+
+// Writer concatenates the pages of several PDF documents into one output
+// document. Each appended document is written out immediately, so only the
+// document currently being appended is held in memory.
+type Writer struct {
+	w       io.Writer
+	offset  int64
+	offsets map[int]int64
+	next    int
+	kids    Array
+	started bool
+	closed  bool
+	err     error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{
+		w:       w,
+		offsets: map[int]int64{},
+		next:    pagesObject + 1,
+	}
+}
+
+func (w *Writer) PageCount() int {
+	return len(w.kids)
+}
+
+// Append copies every page of data, together with the objects those pages
+// reference, to the output. It returns the number of pages added.
+func (w *Writer) Append(data []byte) (int, error) {
+	if w.err != nil {
+		return 0, w.err
+	}
+	if w.closed {
+		return 0, errors.New("pdf: writer is closed")
+	}
+
+	doc, err := parseDocument(data)
+	if err != nil {
+		return 0, err
+	}
+	pages, err := doc.pages()
+	if err != nil {
+		return 0, err
+	}
+	if err := w.start(); err != nil {
+		return 0, err
+	}
+
+	copier := &objectCopier{doc: doc, writer: w, mapped: map[int]int{}, pages: map[int]Dict{}}
+	for _, page := range pages {
+		copier.pages[page.ref.Num] = page.dict
+		w.kids = append(w.kids, copier.mapRef(page.ref))
+	}
+	for len(copier.queue) > 0 {
+		oldNum := copier.queue[0]
+		copier.queue = copier.queue[1:]
+		if err := copier.copyObject(oldNum); err != nil {
+			w.err = err
+			return 0, err
+		}
+	}
+
+	return len(pages), nil
+}
+
+// Close writes the page tree, catalog, cross-reference table and trailer.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.closed {
+		return nil
+	}
+	if err := w.start(); err != nil {
+		return err
+	}
+	w.closed = true
+
+	if err := w.writeIndirect(pagesObject, Dict{
+		"Type":  Name("Pages"),
+		"Kids":  w.kids,
+		"Count": int64(len(w.kids)),
+	}); err != nil {
+		return err
+	}
+	if err := w.writeIndirect(catalogObject, Dict{
+		"Type":  Name("Catalog"),
+		"Pages": Ref{Num: pagesObject},
+	}); err != nil {
+		return err
+	}
+
+	xrefOffset := w.offset
+	if err := w.printf("xref\n0 %d\n0000000000 65535 f \n", w.next); err != nil {
+		return err
+	}
+	for num := 1; num < w.next; num++ {
+		if err := w.printf("%010d 00000 n \n", w.offsets[num]); err != nil {
+			return err
+		}
+	}
+	if err := w.printf("trailer\n"); err != nil {
+		return err
+	}
+	if err := w.writeValue(Dict{"Size": int64(w.next), "Root": Ref{Num: catalogObject}}); err != nil {
+		return err
+	}
+	return w.printf("\nstartxref\n%d\n%%%%EOF\n", xrefOffset)
+}
+
+func (w *Writer) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+	return w.printf("%%PDF-1.7\n%%\xe2\xe3\xcf\xd3\n")
+}
+
+func (w *Writer) allocate() int {
+	num := w.next
+	w.next++
+	return num
+}
+
+func (w *Writer) writeIndirect(num int, object Object) error {
+	w.offsets[num] = w.offset
+	if err := w.printf("%d 0 obj\n", num); err != nil {
+		return err
+	}
+	if err := w.writeValue(object); err != nil {
+		return err
+	}
+	return w.printf("\nendobj\n")
+}
+
+func (w *Writer) writeValue(object Object) error {
+	counter := &countingWriter{w: w.w}
+	err := writeObject(counter, object)
+	w.offset += counter.n
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+func (w *Writer) printf(format string, args ...any) error {
+	n, err := fmt.Fprintf(w.w, format, args...)
+	w.offset += int64(n)
+	if err != nil {
+		w.err = err
+	}
+	return err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type objectCopier struct {
+	doc    *document
+	writer *Writer
+	mapped map[int]int
+	pages  map[int]Dict
+	queue  []int
+}
+
+func (c *objectCopier) mapRef(ref Ref) Object {
+	if num, ok := c.mapped[ref.Num]; ok {
+		return Ref{Num: num}
+	}
+	if _, ok := c.doc.objects[ref.Num]; !ok {
+		return nil
+	}
+	num := c.writer.allocate()
+	c.mapped[ref.Num] = num
+	c.queue = append(c.queue, ref.Num)
+	return Ref{Num: num}
+}
+
+func (c *objectCopier) copyObject(oldNum int) error {
+	object := c.doc.objects[oldNum]
+	if page, ok := c.pages[oldNum]; ok {
+		translated := c.translate(page).(Dict)
+		translated["Parent"] = Ref{Num: pagesObject}
+		object = translated
+	} else {
+		object = c.translate(object)
+	}
+	return c.writer.writeIndirect(c.mapped[oldNum], object)
+}
+
+func (c *objectCopier) translate(object Object) Object {
+	switch typed := object.(type) {
+	case Ref:
+		return c.mapRef(typed)
+	case Array:
+		translated := make(Array, len(typed))
+		for i, item := range typed {
+			translated[i] = c.translate(item)
+		}
+		return translated
+	case Dict:
+		translated := make(Dict, len(typed))
+		for key, value := range typed {
+			if key == "Parent" {
+				if _, isPage := c.pageRef(value); isPage {
+					continue
+				}
+			}
+			translated[key] = c.translate(value)
+		}
+		return translated
+	case *Stream:
+		return &Stream{Dict: c.translate(typed.Dict).(Dict), Data: typed.Data}
+	default:
+		return object
+	}
+}
+
+// pageRef reports whether value points at a page tree node. Those links are
+// dropped because the output builds its own page tree.
+func (c *objectCopier) pageRef(value Object) (Ref, bool) {
+	ref, ok := value.(Ref)
+	if !ok {
+		return Ref{}, false
+	}
+	dict, ok := c.doc.objects[ref.Num].(Dict)
+	if !ok {
+		return Ref{}, false
+	}
+	return ref, dict.Name("Type") == "Pages"
+}
+
+type pageEntry struct {
+	ref  Ref
+	dict Dict
+}
+
+func (d *document) pages() ([]pageEntry, error) {
+	catalog, ok := d.resolve(d.root).(Dict)
+	if !ok {
+		return nil, ErrNoCatalog
+	}
+	root, ok := catalog["Pages"].(Ref)
+	if !ok {
+		return nil, errors.New("pdf: catalog has no page tree")
+	}
+
+	var pages []pageEntry
+	visited := map[int]bool{}
+	var walk func(ref Ref, inherited Dict) error
+	walk = func(ref Ref, inherited Dict) error {
+		if visited[ref.Num] {
+			return nil
+		}
+		visited[ref.Num] = true
+
+		node, ok := d.resolve(ref).(Dict)
+		if !ok {
+			return fmt.Errorf("pdf: page tree node %d is not a dictionary", ref.Num)
+		}
+
+		attributes := make(Dict, len(inherited))
+		for key, value := range inherited {
+			attributes[key] = value
+		}
+		for _, key := range inheritedPageKeys {
+			if value, ok := node[key]; ok {
+				attributes[key] = value
+			}
+		}
+
+		kids, isTree := d.resolve(node["Kids"]).(Array)
+		if node.Name("Type") == "Pages" || (isTree && node.Name("Type") != "Page") {
+			for _, kid := range kids {
+				kidRef, ok := kid.(Ref)
+				if !ok {
+					continue
+				}
+				if err := walk(kidRef, attributes); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+
+		page := make(Dict, len(node)+len(attributes))
+		for key, value := range attributes {
+			page[key] = value
+		}
+		for key, value := range node {
+			page[key] = value
+		}
+		delete(page, "Parent")
+		pages = append(pages, pageEntry{ref: ref, dict: page})
+		return nil
+	}
+
+	if err := walk(root, Dict{}); err != nil {
+		return nil, err
+	}
+	if len(pages) == 0 {
+		return nil, errors.New("pdf: document has no pages")
+	}
+	return pages, nil
+}
@@ -0,0 +1,230 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func buildPDF(objects map[int]string, trailer string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for num := 1; num <= len(objects); num++ {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", num, objects[num])
+	}
+	fmt.Fprintf(&buf, "trailer\n%s\n%%%%EOF\n", trailer)
+	return buf.Bytes()
+}
+
+func streamObject(content string) string {
+	return fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content)
+}
+
+func twoPageDocument(text string) []byte {
+	return buildPDF(map[int]string{
+		1: "<< /Type /Catalog /Pages 2 0 R >>",
+		2: "<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /MediaBox [0 0 288 432] /Resources << /Font << /F1 5 0 R >> >> >>",
+		3: "<< /Type /Page /Parent 2 0 R /Contents 6 0 R >>",
+		4: "<< /Type /Page /Parent 2 0 R /Contents 6 0 R /Rotate 90 >>",
+		5: "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		6: streamObject("BT /F1 12 Tf (" + text + ") Tj ET"),
+	}, "<< /Size 7 /Root 1 0 R >>")
+}
+
+func objectStreamDocument(t *testing.T) []byte {
+	t.Helper()
+
+	packed := []string{
+		"<< /Type /Catalog /Pages 3 0 R >>",
+		"<< /Type /Pages /Kids [4 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 3 0 R /MediaBox [0 0 595 842] /Contents 5 0 R >>",
+	}
+	var header, body strings.Builder
+	for i, object := range packed {
+		fmt.Fprintf(&header, "%d %d ", i+2, body.Len())
+		body.WriteString(object)
+		body.WriteString("\n")
+	}
+	var compressed bytes.Buffer
+	writer := zlib.NewWriter(&compressed)
+	if _, err := writer.Write([]byte(header.String() + body.String())); err != nil {
+		t.Fatalf("compress object stream: %v", err)
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close compressor: %v", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.5\n")
+	fmt.Fprintf(&buf, "1 0 obj\n<< /Type /ObjStm /N 3 /First %d /Filter /FlateDecode /Length %d >>\nstream\n", header.Len(), compressed.Len())
+	buf.Write(compressed.Bytes())
+	buf.WriteString("\nendstream\nendobj\n")
+	content := "0 0 m 10 10 l S"
+	fmt.Fprintf(&buf, "5 0 obj\n%s\nendobj\n", streamObject(content))
+	buf.WriteString("6 0 obj\n<< /Type /XRef /Size 7 /Root 2 0 R /W [1 2 1] /Length 0 >>\nstream\n\nendstream\nendobj\n")
+	buf.WriteString("startxref\n0\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func TestWriterMergesDocuments(t *testing.T) {
+	var out bytes.Buffer
+	writer := NewWriter(&out)
+
+	added, err := writer.Append(twoPageDocument("first"))
+	if err != nil {
+		t.Fatalf("append first: %v", err)
+	}
+	if added != 2 {
+		t.Fatalf("unexpected first page count: %d", added)
+	}
+	added, err = writer.Append(objectStreamDocument(t))
+	if err != nil {
+		t.Fatalf("append object stream document: %v", err)
+	}
+	if added != 1 {
+		t.Fatalf("unexpected second page count: %d", added)
+	}
+	if _, err := writer.Append(twoPageDocument("third")); err != nil {
+		t.Fatalf("append third: %v", err)
+	}
+	if writer.PageCount() != 5 {
+		t.Fatalf("unexpected total page count: %d", writer.PageCount())
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	merged := out.Bytes()
+	assertXRefOffsets(t, merged)
+
+	doc, err := parseDocument(merged)
+	if err != nil {
+		t.Fatalf("parse merged output: %v", err)
+	}
+	pages, err := doc.pages()
+	if err != nil {
+		t.Fatalf("walk merged pages: %v", err)
+	}
+	if len(pages) != 5 {
+		t.Fatalf("unexpected merged page count: %d", len(pages))
+	}
+
+	for i, page := range pages {
+		if _, ok := page.dict["Resources"]; i != 2 && !ok {
+			t.Fatalf("page %d lost inherited resources", i)
+		}
+		if _, ok := page.dict["MediaBox"].(Array); !ok {
+			t.Fatalf("page %d lost its media box", i)
+		}
+		raw := doc.objects[page.ref.Num].(Dict)
+		if raw["Parent"] != (Ref{Num: pagesObject}) {
+			t.Fatalf("page %d has unexpected parent: %#v", i, raw["Parent"])
+		}
+	}
+	if rotate, _ := pages[1].dict["Rotate"].(int64); rotate != 90 {
+		t.Fatalf("unexpected rotate on second page: %#v", pages[1].dict["Rotate"])
+	}
+
+	contents := []string{"first", "", "third"}
+	for i, index := range []int{0, 2, 3} {
+		stream, ok := doc.resolve(pages[index].dict["Contents"]).(*Stream)
+		if !ok {
+			t.Fatalf("page %d has no content stream", index)
+		}
+		if contents[i] != "" && !bytes.Contains(stream.Data, []byte("("+contents[i]+")")) {
+			t.Fatalf("page %d has unexpected content: %q", index, stream.Data)
+		}
+	}
+	if pages[0].dict["Contents"] != pages[1].dict["Contents"] {
+		t.Fatal("expected shared content stream to be copied once")
+	}
+}
+
+func assertXRefOffsets(t *testing.T, data []byte) {
+	t.Helper()
+
+	index := bytes.LastIndex(data, []byte("startxref"))
+	if index < 0 {
+		t.Fatal("missing startxref")
+	}
+	fields := strings.Fields(string(data[index+len("startxref"):]))
+	offset, err := strconv.Atoi(fields[0])
+	if err != nil {
+		t.Fatalf("invalid startxref: %v", err)
+	}
+	if !bytes.HasPrefix(data[offset:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point at xref table: %q", data[offset:offset+10])
+	}
+
+	entry := regexp.MustCompile(`(\d{10}) 00000 n `)
+	for i, match := range entry.FindAllSubmatch(data[offset:], -1) {
+		objectOffset, _ := strconv.Atoi(string(match[1]))
+		want := fmt.Sprintf("%d 0 obj", i+1)
+		if !bytes.HasPrefix(data[objectOffset:], []byte(want)) {
+			t.Fatalf("xref entry %d points at %q", i+1, data[objectOffset:objectOffset+len(want)])
+		}
+	}
+}
+
+func TestWriterRejectsInvalidDocuments(t *testing.T) {
+	encrypted := buildPDF(map[int]string{
+		1: "<< /Type /Catalog /Pages 2 0 R >>",
+		2: "<< /Type /Pages /Kids [] /Count 0 >>",
+	}, "<< /Size 3 /Root 1 0 R /Encrypt << /Filter /Standard >> >>")
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantErr error
+	}{
+		{name: "not a pdf", data: []byte("<html></html>")},
+		{name: "encrypted", data: encrypted, wantErr: ErrEncrypted},
+		{name: "no catalog", data: []byte("%PDF-1.4\n1 0 obj\n<< /Type /Font >>\nendobj\n"), wantErr: ErrNoCatalog},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			writer := NewWriter(&out)
+			_, err := writer.Append(tt.data)
+			if err == nil {
+				t.Fatal("expected error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if out.Len() != 0 {
+				t.Fatalf("expected nothing written, got %q", out.String())
+			}
+		})
+	}
+}
+
+func TestWriteObjectEscapes(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeObject(&buf, Dict{
+		"B": String("a(b)\\c"),
+		"A": Name("with space"),
+		"C": Array{int64(1), Real("2.5"), true, nil, Ref{Num: 4}},
+	}); err != nil {
+		t.Fatalf("write object: %v", err)
+	}
+	want := `<</A /with#20space/B (a\(b\)\\c)/C [1 2.5 true null 4 0 R]>>`
+	if buf.String() != want {
+		t.Fatalf("unexpected output:\n got %s\nwant %s", buf.String(), want)
+	}
+
+	parsed, err := (&parser{data: buf.Bytes()}).parseObject()
+	if err != nil {
+		t.Fatalf("parse written object: %v", err)
+	}
+	dict := parsed.(Dict)
+	if dict.Name("A") != "with space" || string(dict["B"].(String)) != "a(b)\\c" {
+		t.Fatalf("unexpected round trip: %#v", dict)
+	}
+}
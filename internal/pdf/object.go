@@ -0,0 +1,130 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+type Object any
+
+type Name string
+
+type Ref struct {
+	Num int
+	Gen int
+}
+
+type Dict map[Name]Object
+
+type Array []Object
+
+type String []byte
+
+type HexString []byte
+
+type Real string
+
+type Stream struct {
+	Dict Dict
+	Data []byte
+}
+
+func (d Dict) Name(key Name) Name {
+	name, _ := d[key].(Name)
+	return name
+}
+
+func writeObject(w io.Writer, object Object) error {
+	var buf bytes.Buffer
+	appendObject(&buf, object)
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func appendObject(buf *bytes.Buffer, object Object) {
+	switch typed := object.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		buf.WriteString(strconv.FormatBool(typed))
+	case int64:
+		buf.WriteString(strconv.FormatInt(typed, 10))
+	case int:
+		buf.WriteString(strconv.Itoa(typed))
+	case Real:
+		buf.WriteString(string(typed))
+	case Name:
+		appendName(buf, typed)
+	case String:
+		appendLiteral(buf, typed)
+	case HexString:
+		fmt.Fprintf(buf, "<%x>", []byte(typed))
+	case Ref:
+		fmt.Fprintf(buf, "%d %d R", typed.Num, typed.Gen)
+	case Array:
+		buf.WriteByte('[')
+		for i, item := range typed {
+			if i > 0 {
+				buf.WriteByte(' ')
+			}
+			appendObject(buf, item)
+		}
+		buf.WriteByte(']')
+	case Dict:
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, string(key))
+		}
+		sort.Strings(keys)
+		buf.WriteString("<<")
+		for _, key := range keys {
+			appendName(buf, Name(key))
+			buf.WriteByte(' ')
+			appendObject(buf, typed[Name(key)])
+		}
+		buf.WriteString(">>")
+	case *Stream:
+		dict := make(Dict, len(typed.Dict)+1)
+		for key, value := range typed.Dict {
+			dict[key] = value
+		}
+		dict["Length"] = int64(len(typed.Data))
+		appendObject(buf, dict)
+		buf.WriteString("\nstream\n")
+		buf.Write(typed.Data)
+		buf.WriteString("\nendstream")
+	default:
+		buf.WriteString("null")
+	}
+}
+
+func appendName(buf *bytes.Buffer, name Name) {
+	buf.WriteByte('/')
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < '!' || c > '~' || c == '#' || isDelimiter(c) {
+			fmt.Fprintf(buf, "#%02X", c)
+			continue
+		}
+		buf.WriteByte(c)
+	}
+}
+
+func appendLiteral(buf *bytes.Buffer, value String) {
+	buf.WriteByte('(')
+	for _, c := range value {
+		switch c {
+		case '(', ')', '\\':
+			buf.WriteByte('\\')
+			buf.WriteByte(c)
+		case '\r':
+			buf.WriteString(`\r`)
+		default:
+			buf.WriteByte(c)
+		}
+	}
+	buf.WriteByte(')')
+}
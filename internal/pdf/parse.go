@@ -0,0 +1,546 @@
+package pdf
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+)
+
+var (
+	ErrEncrypted = errors.New("pdf: encrypted documents are not supported")
+	ErrNoCatalog = errors.New("pdf: document catalog not found")
+)
+
+var objectHeader = regexp.MustCompile(`(\d+)[\x00\t\n\f\r ]+(\d+)[\x00\t\n\f\r ]+obj`)
+
+type document struct {
+	objects map[int]Object
+	root    Ref
+	hasRoot bool
+}
+
+// parseDocument reads every indirect object in the file, including objects
+// packed into object streams. Objects are located by scanning the body rather
+// than trusting the xref table, which keeps damaged or incrementally updated
+// files readable.
+func parseDocument(data []byte) (*document, error) {
+	if !bytes.HasPrefix(bytes.TrimLeft(data, "\x00\t\n\f\r "), []byte("%PDF-")) {
+		return nil, errors.New("pdf: missing %PDF header")
+	}
+
+	doc := &document{objects: map[int]Object{}}
+	encrypted := false
+	offset := 0
+	for offset < len(data) {
+		match := objectHeader.FindSubmatchIndex(data[offset:])
+		if match == nil {
+			break
+		}
+		start := offset + match[0]
+		if start > 0 && !isSpace(data[start-1]) && !isDelimiter(data[start-1]) {
+			offset += match[1]
+			continue
+		}
+		num, _ := strconv.Atoi(string(data[offset+match[2] : offset+match[3]]))
+		p := &parser{data: data, pos: offset + match[1]}
+		object, err := p.parseIndirectBody()
+		if err != nil {
+			offset += match[1]
+			continue
+		}
+		doc.objects[num] = object
+		offset = p.pos
+
+		if stream, ok := object.(*Stream); ok && stream.Dict.Name("Type") == "XRef" {
+			if root, ok := stream.Dict["Root"].(Ref); ok {
+				doc.root, doc.hasRoot = root, true
+			}
+			if stream.Dict["Encrypt"] != nil {
+				encrypted = true
+			}
+		}
+	}
+
+	for _, index := range trailerOffsets(data) {
+		p := &parser{data: data, pos: index + len("trailer")}
+		object, err := p.parseObject()
+		if err != nil {
+			continue
+		}
+		trailer, ok := object.(Dict)
+		if !ok {
+			continue
+		}
+		if trailer["Encrypt"] != nil {
+			encrypted = true
+		}
+		if root, ok := trailer["Root"].(Ref); ok {
+			doc.root, doc.hasRoot = root, true
+		}
+	}
+	if encrypted {
+		return nil, ErrEncrypted
+	}
+
+	if err := doc.expandObjectStreams(); err != nil {
+		return nil, err
+	}
+
+	if !doc.hasRoot {
+		for num, object := range doc.objects {
+			if dict, ok := object.(Dict); ok && dict.Name("Type") == "Catalog" {
+				doc.root, doc.hasRoot = Ref{Num: num}, true
+				break
+			}
+		}
+	}
+	if !doc.hasRoot {
+		return nil, ErrNoCatalog
+	}
+
+	return doc, nil
+}
+
+func trailerOffsets(data []byte) []int {
+	var offsets []int
+	keyword := []byte("trailer")
+	for offset := 0; ; {
+		index := bytes.Index(data[offset:], keyword)
+		if index < 0 {
+			return offsets
+		}
+		offsets = append(offsets, offset+index)
+		offset += index + len(keyword)
+	}
+}
+
+func (d *document) expandObjectStreams() error {
+	for _, object := range d.objects {
+		stream, ok := object.(*Stream)
+		if !ok || stream.Dict.Name("Type") != "ObjStm" {
+			continue
+		}
+		decoded, err := decodeStream(stream)
+		if err != nil {
+			return err
+		}
+		count, _ := stream.Dict["N"].(int64)
+		first, _ := stream.Dict["First"].(int64)
+		if first < 0 || int(first) > len(decoded) {
+			return fmt.Errorf("pdf: invalid object stream offset %d", first)
+		}
+
+		header := &parser{data: decoded[:first]}
+		for i := int64(0); i < count; i++ {
+			numObject, err := header.parseObject()
+			if err != nil {
+				return err
+			}
+			offsetObject, err := header.parseObject()
+			if err != nil {
+				return err
+			}
+			num, _ := numObject.(int64)
+			offset, _ := offsetObject.(int64)
+			if _, exists := d.objects[int(num)]; exists {
+				continue
+			}
+			body := &parser{data: decoded, pos: int(first + offset)}
+			value, err := body.parseObject()
+			if err != nil {
+				return err
+			}
+			d.objects[int(num)] = value
+		}
+	}
+	return nil
+}
+
+func (d *document) resolve(object Object) Object {
+	for i := 0; i < 32; i++ {
+		ref, ok := object.(Ref)
+		if !ok {
+			return object
+		}
+		object = d.objects[ref.Num]
+	}
+	return nil
+}
+
+func decodeStream(stream *Stream) ([]byte, error) {
+	var filter Name
+	switch typed := stream.Dict["Filter"].(type) {
+	case nil:
+		return stream.Data, nil
+	case Name:
+		filter = typed
+	case Array:
+		if len(typed) == 0 {
+			return stream.Data, nil
+		}
+		if len(typed) > 1 {
+			return nil, fmt.Errorf("pdf: chained stream filters are not supported")
+		}
+		filter, _ = typed[0].(Name)
+	}
+	if filter != "FlateDecode" {
+		return nil, fmt.Errorf("pdf: unsupported stream filter %q", filter)
+	}
+	reader, err := zlib.NewReader(bytes.NewReader(stream.Data))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+	return io.ReadAll(reader)
+}
+
+type parser struct {
+	data []byte
+	pos  int
+}
+
+func (p *parser) parseIndirectBody() (Object, error) {
+	object, err := p.parseObject()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.consumeKeyword("stream") {
+		p.consumeKeyword("endobj")
+		return object, nil
+	}
+
+	dict, ok := object.(Dict)
+	if !ok {
+		return nil, errors.New("pdf: stream without dictionary")
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\r' {
+		p.pos++
+	}
+	if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+		p.pos++
+	}
+	start := p.pos
+
+	end := -1
+	if length, ok := dict["Length"].(int64); ok && length >= 0 && start+int(length) <= len(p.data) {
+		rest := bytes.TrimLeft(p.data[start+int(length):], "\x00\t\n\f\r ")
+		if bytes.HasPrefix(rest, []byte("endstream")) {
+			end = start + int(length)
+		}
+	}
+	if end < 0 {
+		index := bytes.Index(p.data[start:], []byte("endstream"))
+		if index < 0 {
+			return nil, errors.New("pdf: unterminated stream")
+		}
+		end = start + index
+		if end > start && p.data[end-1] == '\n' {
+			end--
+		}
+		if end > start && p.data[end-1] == '\r' {
+			end--
+		}
+	}
+
+	stream := &Stream{Dict: dict, Data: p.data[start:end]}
+	p.pos = end
+	p.skipSpace()
+	p.consumeKeyword("endstream")
+	p.skipSpace()
+	p.consumeKeyword("endobj")
+	return stream, nil
+}
+
+func (p *parser) parseObject() (Object, error) {
+	p.skipSpace()
+	if p.pos >= len(p.data) {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	c := p.data[p.pos]
+	switch {
+	case c == '/':
+		return p.parseName(), nil
+	case c == '(':
+		return p.parseLiteral()
+	case c == '<' && p.peek(1) == '<':
+		return p.parseDict()
+	case c == '<':
+		return p.parseHex()
+	case c == '[':
+		return p.parseArray()
+	case c == '+' || c == '-' || c == '.' || isDigit(c):
+		return p.parseNumberOrRef()
+	}
+
+	keyword := p.readKeyword()
+	switch keyword {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("pdf: unexpected token %q at offset %d", keyword, p.pos)
+}
+
+func (p *parser) parseName() Name {
+	p.pos++
+	var name []byte
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if isSpace(c) || isDelimiter(c) {
+			break
+		}
+		if c == '#' && p.pos+2 < len(p.data) {
+			if value, err := strconv.ParseUint(string(p.data[p.pos+1:p.pos+3]), 16, 8); err == nil {
+				name = append(name, byte(value))
+				p.pos += 3
+				continue
+			}
+		}
+		name = append(name, c)
+		p.pos++
+	}
+	return Name(name)
+}
+
+func (p *parser) parseLiteral() (Object, error) {
+	p.pos++
+	var value []byte
+	depth := 1
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return String(value), nil
+			}
+		case '\\':
+			if p.pos >= len(p.data) {
+				return nil, io.ErrUnexpectedEOF
+			}
+			escaped := p.data[p.pos]
+			p.pos++
+			switch escaped {
+			case 'n':
+				value = append(value, '\n')
+			case 'r':
+				value = append(value, '\r')
+			case 't':
+				value = append(value, '\t')
+			case 'b':
+				value = append(value, '\b')
+			case 'f':
+				value = append(value, '\f')
+			case '\r':
+				if p.pos < len(p.data) && p.data[p.pos] == '\n' {
+					p.pos++
+				}
+			case '\n':
+			default:
+				if escaped >= '0' && escaped <= '7' {
+					octal := int(escaped - '0')
+					for i := 0; i < 2 && p.pos < len(p.data) && p.data[p.pos] >= '0' && p.data[p.pos] <= '7'; i++ {
+						octal = octal*8 + int(p.data[p.pos]-'0')
+						p.pos++
+					}
+					value = append(value, byte(octal))
+					continue
+				}
+				value = append(value, escaped)
+			}
+			continue
+		}
+		value = append(value, c)
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (p *parser) parseHex() (Object, error) {
+	p.pos++
+	var digits []byte
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		p.pos++
+		if c == '>' {
+			if len(digits)%2 == 1 {
+				digits = append(digits, '0')
+			}
+			value := make([]byte, len(digits)/2)
+			for i := range value {
+				parsed, err := strconv.ParseUint(string(digits[i*2:i*2+2]), 16, 8)
+				if err != nil {
+					return nil, err
+				}
+				value[i] = byte(parsed)
+			}
+			return HexString(value), nil
+		}
+		if !isSpace(c) {
+			digits = append(digits, c)
+		}
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+func (p *parser) parseArray() (Object, error) {
+	p.pos++
+	array := Array{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if p.data[p.pos] == ']' {
+			p.pos++
+			return array, nil
+		}
+		item, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		array = append(array, item)
+	}
+}
+
+func (p *parser) parseDict() (Object, error) {
+	p.pos += 2
+	dict := Dict{}
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.data) {
+			return nil, io.ErrUnexpectedEOF
+		}
+		if p.data[p.pos] == '>' && p.peek(1) == '>' {
+			p.pos += 2
+			return dict, nil
+		}
+		if p.data[p.pos] != '/' {
+			return nil, fmt.Errorf("pdf: expected name key at offset %d", p.pos)
+		}
+		key := p.parseName()
+		value, err := p.parseObject()
+		if err != nil {
+			return nil, err
+		}
+		dict[key] = value
+	}
+}
+
+func (p *parser) parseNumberOrRef() (Object, error) {
+	token := p.readNumber()
+	if bytes.ContainsAny(token, ".") {
+		return Real(token), nil
+	}
+	value, err := strconv.ParseInt(string(token), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("pdf: invalid number %q", token)
+	}
+	if token[0] == '+' || token[0] == '-' {
+		return value, nil
+	}
+
+	saved := p.pos
+	p.skipSpace()
+	if p.pos < len(p.data) && isDigit(p.data[p.pos]) {
+		genToken := p.readNumber()
+		gen, err := strconv.Atoi(string(genToken))
+		if err == nil {
+			p.skipSpace()
+			if p.consumeKeyword("R") {
+				return Ref{Num: int(value), Gen: gen}, nil
+			}
+		}
+	}
+	p.pos = saved
+	return value, nil
+}
+
+func (p *parser) readNumber() []byte {
+	start := p.pos
+	if p.pos < len(p.data) && (p.data[p.pos] == '+' || p.data[p.pos] == '-') {
+		p.pos++
+	}
+	for p.pos < len(p.data) && (isDigit(p.data[p.pos]) || p.data[p.pos] == '.') {
+		p.pos++
+	}
+	return p.data[start:p.pos]
+}
+
+func (p *parser) readKeyword() string {
+	start := p.pos
+	for p.pos < len(p.data) && !isSpace(p.data[p.pos]) && !isDelimiter(p.data[p.pos]) {
+		p.pos++
+	}
+	if p.pos == start && p.pos < len(p.data) {
+		p.pos++
+	}
+	return string(p.data[start:p.pos])
+}
+
+func (p *parser) consumeKeyword(keyword string) bool {
+	if !bytes.HasPrefix(p.data[p.pos:], []byte(keyword)) {
+		return false
+	}
+	end := p.pos + len(keyword)
+	if end < len(p.data) && !isSpace(p.data[end]) && !isDelimiter(p.data[end]) {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.data) {
+		c := p.data[p.pos]
+		if c == '%' {
+			for p.pos < len(p.data) && p.data[p.pos] != '\n' && p.data[p.pos] != '\r' {
+				p.pos++
+			}
+			continue
+		}
+		if !isSpace(c) {
+			return
+		}
+		p.pos++
+	}
+}
+
+func (p *parser) peek(offset int) byte {
+	if p.pos+offset < len(p.data) {
+		return p.data[p.pos+offset]
+	}
+	return 0
+}
+
+func isSpace(c byte) bool {
+	switch c {
+	case 0, '\t', '\n', '\f', '\r', ' ':
+		return true
+	}
+	return false
+}
+
+func isDelimiter(c byte) bool {
+	switch c {
+	case '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,84 @@
+package international
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+)
+
+func (r *KYCRequest) hasReaders() bool {
+	for _, document := range r.Documents {
+		for _, attachment := range document.Attachment {
+			if attachment.Reader != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readerPlaceholder stands in for a reader attachment's file while the rest
+// of the request is marshalled. Its JSON form cannot occur in base64 text.
+const readerPlaceholder = "\x00reader\x00"
+
+// writeJSON marshals the request with encoding/json and streams each reader
+// attachment into its file field as base64.
+func (r *KYCRequest) writeJSON(w io.Writer) error {
+	request := *r
+	request.Documents = make([]KYCDocument, len(r.Documents))
+	var readers []io.Reader
+	for i, document := range r.Documents {
+		attachments := make([]KYCAttachment, len(document.Attachment))
+		for j, attachment := range document.Attachment {
+			if attachment.Reader != nil {
+				readers = append(readers, attachment.Reader)
+				attachment = KYCAttachment{File: readerPlaceholder}
+			}
+			attachments[j] = attachment
+		}
+		document.Attachment = attachments
+		request.Documents[i] = document
+	}
+
+	encoded, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	placeholder, err := json.Marshal(readerPlaceholder)
+	if err != nil {
+		return err
+	}
+	for _, reader := range readers {
+		at := bytes.Index(encoded, placeholder)
+		if at < 0 {
+			return errors.New("international: KYC attachment placeholder not found")
+		}
+		if _, err := w.Write(encoded[:at]); err != nil {
+			return err
+		}
+		if err := writeBase64String(w, reader); err != nil {
+			return err
+		}
+		encoded = encoded[at+len(placeholder):]
+	}
+	_, err = w.Write(encoded)
+	return err
+}
+
+// writeBase64String writes the content of r as a quoted base64 JSON string.
+func writeBase64String(w io.Writer, r io.Reader) error {
+	if _, err := io.WriteString(w, `"`); err != nil {
+		return err
+	}
+	encoder := base64.NewEncoder(base64.StdEncoding, w)
+	if _, err := io.Copy(encoder, r); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, `"`)
+	return err
+}
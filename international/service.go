@@ -2,6 +2,7 @@ package international
 
 import (
 	"context"
+	"io"
 	"net/http"
 
 	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
@@ -23,128 +24,151 @@ func NewService(client *internalclient.Client) *Service {
 	}
 }
 
-func (s *Service) TrackOrders(ctx context.Context) (*TrackOrdersResponse, error) {
+func (s *Service) TrackOrders(ctx context.Context, opts ...internalclient.CallOption) (*TrackOrdersResponse, error) {
 	var response TrackOrdersResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/international/orders/track",
-	}, &response); err != nil {
+		Operation: "international.TrackOrders",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/international/orders/track",
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) SubmitKYC(ctx context.Context, request *KYCRequest) (*KYCResponse, error) {
+func (s *Service) SubmitKYC(ctx context.Context, request *KYCRequest, opts ...internalclient.CallOption) (*KYCResponse, error) {
+	req := &internalclient.Request{
+		Operation: "international.SubmitKYC",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/settings/international_kyc",
+		JSONBody:  request,
+	}
+	if request != nil && request.hasReaders() {
+		reader, writer := io.Pipe()
+		// Closing the reader once the call returns stops the writer even
+		// when the request was never sent.
+		defer reader.Close()
+		go func() {
+			_ = writer.CloseWithError(request.writeJSON(writer))
+		}()
+		req.JSONBody = nil
+		req.RawBody = reader
+		req.ContentType = "application/json"
+	}
+
 	var response KYCResponse
-	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/settings/international_kyc",
-		JSONBody: request,
-	}, &response); err != nil {
+	if err := s.client.Do(ctx, req, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) AddBankDetails(ctx context.Context, request *BankDetailsRequest) (*BankDetailsResponse, error) {
+func (s *Service) AddBankDetails(ctx context.Context, request *BankDetailsRequest, opts ...internalclient.CallOption) (*BankDetailsResponse, error) {
 	var response BankDetailsResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/settings/add-bank-details",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.AddBankDetails",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/settings/add-bank-details",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CreateOrder(ctx context.Context, request *OrderRequest) (*OrderResponse, error) {
+func (s *Service) CreateOrder(ctx context.Context, request *OrderRequest, opts ...internalclient.CallOption) (*OrderResponse, error) {
 	var response OrderResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/orders/create/adhoc",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.CreateOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/orders/create/adhoc",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) UpdateOrder(ctx context.Context, request *OrderRequest) (*UpdateOrderResponse, error) {
+func (s *Service) UpdateOrder(ctx context.Context, request *OrderRequest, opts ...internalclient.CallOption) (*UpdateOrderResponse, error) {
 	var response UpdateOrderResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/orders/update/adhoc",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.UpdateOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/orders/update/adhoc",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CreateForwardShipment(ctx context.Context, request *ForwardShipmentRequest) (*ForwardShipmentResponse, error) {
+func (s *Service) CreateForwardShipment(ctx context.Context, request *ForwardShipmentRequest, opts ...internalclient.CallOption) (*ForwardShipmentResponse, error) {
 	var response ForwardShipmentResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/shipments/create/forward-shipment",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.CreateForwardShipment",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/shipments/create/forward-shipment",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CheckServiceability(ctx context.Context, params *ServiceabilityParams) (*ServiceabilityResponse, error) {
+func (s *Service) CheckServiceability(ctx context.Context, params *ServiceabilityParams, opts ...internalclient.CallOption) (*ServiceabilityResponse, error) {
 	var response ServiceabilityResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/international/courier/serviceability",
+		Operation: "international.CheckServiceability",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/international/courier/serviceability",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest) (*courier.AssignAWBResponse, error) {
+func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest, opts ...internalclient.CallOption) (*courier.AssignAWBResponse, error) {
 	var response courier.AssignAWBResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/courier/assign/awb",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.AssignAWB",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/courier/assign/awb",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) GenerateManifest(ctx context.Context, request *shipment.GenerateManifestRequest) (*shipment.GenerateManifestResponse, error) {
+func (s *Service) GenerateManifest(ctx context.Context, request *shipment.GenerateManifestRequest, opts ...internalclient.CallOption) (*shipment.GenerateManifestResponse, error) {
 	var response shipment.GenerateManifestResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/international/manifests/generate",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "international.GenerateManifest",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/international/manifests/generate",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) GeneratePickup(ctx context.Context, request *courier.GeneratePickupRequest) (*courier.GeneratePickupResponse, error) {
-	return s.couriers.GeneratePickup(ctx, request)
+func (s *Service) GeneratePickup(ctx context.Context, request *courier.GeneratePickupRequest, opts ...internalclient.CallOption) (*courier.GeneratePickupResponse, error) {
+	return s.couriers.GeneratePickup(ctx, request, opts...)
 }
 
-func (s *Service) TrackByAWB(ctx context.Context, request *shipment.TrackByAWBRequest) (*shipment.TrackingResponse, error) {
-	return s.tracking.TrackByAWB(ctx, request)
+func (s *Service) TrackByAWB(ctx context.Context, request *shipment.TrackByAWBRequest, opts ...internalclient.CallOption) (*shipment.TrackingResponse, error) {
+	return s.tracking.TrackByAWB(ctx, request, opts...)
 }
 
-func (s *Service) TrackByShipmentID(ctx context.Context, request *shipment.TrackByShipmentIDRequest) (*shipment.TrackingResponse, error) {
-	return s.tracking.TrackByShipmentID(ctx, request)
+func (s *Service) TrackByShipmentID(ctx context.Context, request *shipment.TrackByShipmentIDRequest, opts ...internalclient.CallOption) (*shipment.TrackingResponse, error) {
+	return s.tracking.TrackByShipmentID(ctx, request, opts...)
 }
 
-func (s *Service) TrackByOrder(ctx context.Context, request *shipment.TrackByOrderRequest) (shipment.OrderTrackingResponse, error) {
-	return s.tracking.TrackByOrder(ctx, request)
+func (s *Service) TrackByOrder(ctx context.Context, request *shipment.TrackByOrderRequest, opts ...internalclient.CallOption) (shipment.OrderTrackingResponse, error) {
+	return s.tracking.TrackByOrder(ctx, request, opts...)
 }
@@ -2,8 +2,11 @@ package international
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
@@ -176,3 +179,41 @@ func TestInternationalEndpoints(t *testing.T) {
 		t.Fatalf("unexpected track order response: %+v err=%v", trackOrder, err)
 	}
 }
+
+func TestSubmitKYCStreamsReaderAttachments(t *testing.T) {
+	document := strings.Repeat("%PDF-1.4 scanned page ", 4096)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/json" || r.ContentLength != -1 {
+			t.Fatalf("expected a streamed JSON body, got %q length %d", r.Header.Get("Content-Type"), r.ContentLength)
+		}
+		var request KYCRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("decode request: %v", err)
+		}
+		if request.OrganizationType != "Sole \"Proprietor\"" || len(request.Documents) != 2 {
+			t.Fatalf("unexpected request: %+v", request)
+		}
+		decoded, err := base64.StdEncoding.DecodeString(request.Documents[0].Attachment[0].File)
+		if err != nil || string(decoded) != document {
+			t.Fatalf("unexpected streamed attachment: %d bytes err=%v", len(decoded), err)
+		}
+		if back, _ := base64.StdEncoding.DecodeString(request.Documents[1].Attachment[1].File); request.Documents[1].Attachment[0].File != "base64-blob" || string(back) != "back side" {
+			t.Fatalf("unexpected attachments: %+v", request.Documents[1])
+		}
+		_, _ = w.Write([]byte(`{"success":true,"message":"Document is successfully updated!"}`))
+	}))
+	defer server.Close()
+
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	kyc, err := s.SubmitKYC(context.Background(), &KYCRequest{
+		OrganizationType: "Sole \"Proprietor\"",
+		IPAddress:        "35.207.230.249",
+		Documents: []KYCDocument{
+			{Attachment: []KYCAttachment{{Reader: strings.NewReader(document)}}},
+			{Attachment: []KYCAttachment{{File: "base64-blob"}, {Reader: strings.NewReader("back side")}}},
+		},
+	})
+	if err != nil || !kyc.Success {
+		t.Fatalf("unexpected kyc response: %+v err=%v", kyc, err)
+	}
+}
@@ -2,6 +2,7 @@ package international
 
 import (
 	"encoding/json"
+	"io"
 	"net/url"
 	"strconv"
 
@@ -22,8 +23,11 @@ type KYCDocument struct {
 	Attachment []KYCAttachment `json:"attachment"`
 }
 
+// KYCAttachment holds a document as base64 in File, or a Reader whose
+// content SubmitKYC encodes while streaming the request.
 type KYCAttachment struct {
-	File string `json:"file"`
+	File   string    `json:"file"`
+	Reader io.Reader `json:"-"`
 }
 
 type KYCResponse struct {
@@ -15,28 +15,29 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, params *ListParams, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
-	request := &internalclient.Request{Method: http.MethodGet, Path: "/v1/external/inventory"}
+	request := &internalclient.Request{Operation: "inventory.List", Method: http.MethodGet, Path: "/v1/external/inventory"}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Update(ctx context.Context, request *UpdateRequest) (*UpdateResponse, error) {
+func (s *Service) Update(ctx context.Context, request *UpdateRequest, opts ...internalclient.CallOption) (*UpdateResponse, error) {
 	var response UpdateResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPut,
-		Path:   "/v1/external/inventory/{product_id}/update",
+		Operation: "inventory.Update",
+		Method:    http.MethodPut,
+		Path:      "/v1/external/inventory/{product_id}/update",
 		PathParams: map[string]string{
 			"product_id": request.ProductID,
 		},
 		JSONBody: request.Payload,
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -0,0 +1,224 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/account"
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/listings"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/orders"
+)
+
+type Service struct {
+	client   *internalclient.Client
+	accounts *account.Service
+	listings *listings.Service
+	orders   *orders.Service
+}
+
+func NewService(client *internalclient.Client) *Service {
+	return &Service{
+		client:   client,
+		accounts: account.NewService(client),
+		listings: listings.NewService(client),
+		orders:   orders.NewService(client),
+	}
+}
+
+// Import tracks a bulk file import through the import status endpoint. The ID
+// is the one returned by Orders.ImportOrders, Products.Import or Listings.Import.
+func (s *Service) Import(importID int64) Job {
+	return &importJob{service: s, importID: strconv.FormatInt(importID, 10)}
+}
+
+// Export tracks an export whose file becomes available at a download URL.
+// start is called once, on the first poll, and later polls probe the URL it
+// returned until the file can be fetched. The job fails if start returns no
+// URL.
+func (s *Service) Export(start func(ctx context.Context) (string, error)) Job {
+	return &exportJob{service: s, start: start}
+}
+
+func (s *Service) ListingsExportMapped() Job {
+	return s.Export(func(ctx context.Context) (string, error) {
+		response, err := s.listings.ExportMapped(ctx)
+		if err != nil {
+			return "", err
+		}
+		return response.DownloadURL, nil
+	})
+}
+
+func (s *Service) ListingsExportUnmapped() Job {
+	return s.Export(func(ctx context.Context) (string, error) {
+		response, err := s.listings.ExportUnmapped(ctx)
+		if err != nil {
+			return "", err
+		}
+		return response.DownloadURL, nil
+	})
+}
+
+// OrdersExport starts an order export on the first poll. Shiprocket emails
+// the export link to the API user instead of returning it, so the job
+// completes as soon as the export is accepted.
+func (s *Service) OrdersExport(request *orders.ExportOrdersRequest) Job {
+	return &ordersExportJob{service: s, request: request}
+}
+
+func (s *Service) Wait(ctx context.Context, job Job, opts *Options) (*Result, error) {
+	return Wait(ctx, job, opts)
+}
+
+// OrderImportChecker waits on imports for orders.BulkImporter, which cannot
+// depend on this package directly.
+func (s *Service) OrderImportChecker(opts *Options) orders.ImportChecker {
+	return &orderImportChecker{service: s, opts: opts}
+}
+
+type orderImportChecker struct {
+	service *Service
+	opts    *Options
+}
+
+func (c *orderImportChecker) WaitImport(ctx context.Context, importID int64) (*orders.ImportStatus, error) {
+	result, err := c.service.Wait(ctx, c.service.Import(importID), c.opts)
+	if result == nil || result.State == "" {
+		return nil, err
+	}
+	status := &orders.ImportStatus{
+		Status:  result.Status,
+		Message: result.Message,
+		Failed:  result.State == StateFailed,
+	}
+	for _, row := range result.Errors {
+		status.Errors = append(status.Errors, orders.ImportErrorRow{
+			Line:       row.Line,
+			SourceLine: row.SourceLine,
+			Message:    row.Message,
+			Fields:     row.Fields,
+		})
+	}
+	return status, err
+}
+
+type importJob struct {
+	service  *Service
+	importID string
+}
+
+func (j *importJob) Poll(ctx context.Context) (*Snapshot, error) {
+	response, err := j.service.accounts.CheckImport(ctx, &account.ImportCheckRequest{ImportID: j.importID})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{
+		State:        importState(response.Data.Status),
+		Status:       response.Data.Status,
+		Message:      response.Data.Message,
+		ErrorFileURL: response.Data.ErrorFileURL,
+	}
+	if snapshot.ErrorFileURL == "" && isURL(snapshot.Message) {
+		snapshot.ErrorFileURL = strings.TrimSpace(snapshot.Message)
+	}
+	return snapshot, nil
+}
+
+func (j *importJob) OpenErrorFile(ctx context.Context, fileURL string) (io.ReadCloser, error) {
+	return j.service.client.StreamURL(ctx, "jobs.OpenErrorFile", fileURL, nil)
+}
+
+// importState maps the import status codes and labels returned by Shiprocket.
+// Status 3 is what the API reports when the uploaded file could not be read.
+func importState(status string) State {
+	switch strings.ToLower(strings.TrimSpace(status)) {
+	case "", "0", "pending", "queued":
+		return StatePending
+	case "2", "completed", "complete", "success", "done", "processed":
+		return StateCompleted
+	case "3", "4", "failed", "failure", "error":
+		return StateFailed
+	default:
+		return StateRunning
+	}
+}
+
+type exportJob struct {
+	service *Service
+	start   func(ctx context.Context) (string, error)
+	started bool
+	url     string
+}
+
+func (j *exportJob) Poll(ctx context.Context) (*Snapshot, error) {
+	if !j.started {
+		url, err := j.start(ctx)
+		if err != nil {
+			return nil, err
+		}
+		j.started, j.url = true, url
+	}
+	if j.url == "" {
+		return &Snapshot{State: StateFailed, Message: "export returned no download URL"}, nil
+	}
+
+	// Presigned URLs are usually signed for GET only, so readiness is probed
+	// with a one-byte range rather than HEAD.
+	stream, err := j.service.client.StreamURL(ctx, "jobs.Wait", j.url, &internalclient.StreamOptions{Length: 1})
+	if err != nil {
+		if notReady(err) {
+			return &Snapshot{State: StateRunning, DownloadURL: j.url}, nil
+		}
+		return nil, err
+	}
+	_ = stream.Close()
+
+	return &Snapshot{State: StateCompleted, DownloadURL: j.url}, nil
+}
+
+type ordersExportJob struct {
+	service  *Service
+	request  *orders.ExportOrdersRequest
+	snapshot *Snapshot
+}
+
+func (j *ordersExportJob) Poll(ctx context.Context) (*Snapshot, error) {
+	if j.snapshot == nil {
+		response, err := j.service.orders.ExportOrders(ctx, j.request)
+		if err != nil {
+			return nil, err
+		}
+		message := "export accepted"
+		if response.IsBackgroundDownloading.Bool() {
+			message = "export is being prepared and will be emailed"
+		}
+		j.snapshot = &Snapshot{State: StateCompleted, Status: strconv.Itoa(response.Status), Message: message}
+	}
+	snapshot := *j.snapshot
+	return &snapshot, nil
+}
+
+// notReady reports whether the export host has not published the file yet.
+// Object stores answer 403 or 404 until the export has been written.
+func notReady(err error) bool {
+	var authErr *internalclient.AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Meta.StatusCode == http.StatusForbidden
+	}
+	var businessErr *internalclient.BusinessError
+	if errors.As(err, &businessErr) {
+		return businessErr.Meta.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
+func isURL(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, "https://") || strings.HasPrefix(value, "http://")
+}
@@ -0,0 +1,202 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+)
+
+var fastPolling = &Options{InitialInterval: time.Millisecond, MaxInterval: 4 * time.Millisecond}
+
+func TestWaitPollsImportAndParsesErrorFile(t *testing.T) {
+	statuses := []string{"0", "1", "2"}
+	polls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/errors/9001/check":
+			status := statuses[polls]
+			polls++
+			errorFile := ""
+			if status == "2" {
+				errorFile = server.URL + "/files/errors.csv"
+			}
+			_, _ = fmt.Fprintf(w, `{"data":{"status":"%s","message":"Processing","error_file_url":"%s"}}`, status, errorFile)
+		case "/files/errors.csv":
+			if r.Header.Get("Authorization") != "" {
+				t.Fatalf("error file request must not carry the API token")
+			}
+			w.Header().Set("Content-Type", "text/csv")
+			_, _ = w.Write([]byte("\ufeffRow Number,Order Id,Error\n3,ORD-1,Invalid pincode\n7,ORD-5,\"SKU missing, check catalog\"\n"))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+
+	var progress []Snapshot
+	options := *fastPolling
+	options.OnProgress = func(snapshot Snapshot) {
+		progress = append(progress, snapshot)
+	}
+	result, err := service.Wait(context.Background(), service.Import(9001), &options)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+
+	if len(progress) != 3 || progress[0].State != StatePending || progress[1].State != StateRunning || progress[2].Attempt != 3 {
+		t.Fatalf("unexpected progress: %+v", progress)
+	}
+	if result.State != StateCompleted || result.Status != "2" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(result.Errors) != 2 {
+		t.Fatalf("unexpected error rows: %+v", result.Errors)
+	}
+	first, second := result.Errors[0], result.Errors[1]
+	if first.Line != 2 || first.SourceLine != 3 || first.Message != "Invalid pincode" || first.Fields["Order Id"] != "ORD-1" {
+		t.Fatalf("unexpected first error row: %+v", first)
+	}
+	if second.SourceLine != 7 || second.Message != "SKU missing, check catalog" {
+		t.Fatalf("unexpected second error row: %+v", second)
+	}
+}
+
+func TestWaitReturnsFailedImport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"status":"3","message":"Error in reading file data!"}}`))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	result, err := Wait(context.Background(), service.Import(20212061), fastPolling)
+	if !errors.Is(err, ErrJobFailed) || !strings.Contains(err.Error(), "Error in reading file data!") {
+		t.Fatalf("expected ErrJobFailed, got %v", err)
+	}
+	if result.State != StateFailed || result.Attempt != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+}
+
+func TestOrderImportCheckerReportsErrorRows(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/errors/42/check":
+			_, _ = fmt.Fprintf(w, `{"data":{"status":"3","message":"Some rows failed","error_file_url":"%s/files/errors.csv"}}`, server.URL)
+		case "/files/errors.csv":
+			_, _ = w.Write([]byte("Row Number,Order Id,Error\n3,ORD-2,Invalid pincode\n"))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	status, err := service.OrderImportChecker(fastPolling).WaitImport(context.Background(), 42)
+	if !errors.Is(err, ErrJobFailed) {
+		t.Fatalf("expected ErrJobFailed, got %v", err)
+	}
+	if status == nil || !status.Failed || len(status.Errors) != 1 {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if row := status.Errors[0]; row.SourceLine != 3 || row.Message != "Invalid pincode" || row.Fields["Order Id"] != "ORD-2" {
+		t.Fatalf("unexpected error row: %+v", row)
+	}
+}
+
+func TestWaitFollowsExportDownloadURL(t *testing.T) {
+	starts, probes := 0, 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/listings/export/unmapped":
+			starts++
+			_, _ = fmt.Fprintf(w, `{"download_url":"%s/exports/unmapped.csv"}`, server.URL)
+		case "/exports/unmapped.csv":
+			probes++
+			if r.Method != http.MethodGet || r.Header.Get("Range") != "bytes=0-0" {
+				t.Fatalf("expected a one-byte ranged probe, got %s Range=%q", r.Method, r.Header.Get("Range"))
+			}
+			if probes < 3 {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchKey</Code></Error>`))
+				return
+			}
+			_, _ = w.Write([]byte("sku,name\n"))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	result, err := Wait(context.Background(), service.ListingsExportUnmapped(), fastPolling)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if result.State != StateCompleted || result.Attempt != 3 || result.DownloadURL != server.URL+"/exports/unmapped.csv" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if starts != 1 {
+		t.Fatalf("expected the export to be started once, got %d", starts)
+	}
+}
+
+func TestOrdersExportStartsOnce(t *testing.T) {
+	starts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/external/orders/export" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		starts++
+		_, _ = w.Write([]byte(`{"status":200,"is_background_downloading":true}`))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	job := service.OrdersExport(nil)
+	result, err := Wait(context.Background(), job, fastPolling)
+	if err != nil {
+		t.Fatalf("Wait returned error: %v", err)
+	}
+	if result.State != StateCompleted || result.Status != "200" || !strings.Contains(result.Message, "emailed") {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if _, err := job.Poll(context.Background()); err != nil || starts != 1 {
+		t.Fatalf("expected later polls not to start another export, got %d starts err=%v", starts, err)
+	}
+}
+
+func TestWaitTimesOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"status":"1","message":"Processing"}}`))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	options := *fastPolling
+	options.Timeout = 20 * time.Millisecond
+	result, err := Wait(context.Background(), service.Import(1), &options)
+	if !errors.Is(err, ErrTimeout) {
+		t.Fatalf("expected ErrTimeout, got %v", err)
+	}
+	if result.State != StateRunning || result.Attempt < 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := Wait(ctx, service.Import(1), fastPolling); errors.Is(err, ErrTimeout) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected caller cancellation, got %v", err)
+	}
+}
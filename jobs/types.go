@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type State string
+
+const (
+	StatePending   State = "pending"
+	StateRunning   State = "running"
+	StateCompleted State = "completed"
+	StateFailed    State = "failed"
+)
+
+func (s State) Done() bool {
+	return s == StateCompleted || s == StateFailed
+}
+
+// Snapshot is the state of a job after one poll. Attempt and Elapsed are
+// filled in by Wait.
+type Snapshot struct {
+	State        State
+	Status       string
+	Message      string
+	DownloadURL  string
+	ErrorFileURL string
+	Attempt      int
+	Elapsed      time.Duration
+}
+
+// Job is a server-side import or export that can be polled until it settles.
+type Job interface {
+	Poll(ctx context.Context) (*Snapshot, error)
+}
+
+// ErrorFileOpener is implemented by jobs that can fetch their own error file.
+// Wait uses it to download and parse the rows the server rejected.
+type ErrorFileOpener interface {
+	OpenErrorFile(ctx context.Context, fileURL string) (io.ReadCloser, error)
+}
+
+type Options struct {
+	// InitialInterval is the delay before the second poll. Defaults to 2s.
+	InitialInterval time.Duration
+	// MaxInterval caps the backoff between polls. Defaults to 30s.
+	MaxInterval time.Duration
+	// Multiplier grows the interval after each poll. Defaults to 2.
+	Multiplier float64
+	// Timeout bounds the whole wait. Zero relies on the context deadline.
+	Timeout time.Duration
+	// OnProgress is called after every poll.
+	OnProgress func(Snapshot)
+	// SkipErrorFile disables downloading and parsing the error file.
+	SkipErrorFile bool
+}
+
+type Result struct {
+	Snapshot
+	Errors []ErrorRow
+}
+
+// ErrorRow is one rejected row from a job's error file. Line is the record's
+// position in the error file, counting the header as line 1. SourceLine is the
+// line of the uploaded file the server reported, when the file includes one.
+type ErrorRow struct {
+	Line       int
+	SourceLine int
+	Message    string
+	Fields     map[string]string
+}
@@ -0,0 +1,185 @@
+package jobs
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrJobFailed = errors.New("job failed")
+	ErrTimeout   = errors.New("job did not finish before the timeout")
+)
+
+const (
+	defaultInitialInterval = 2 * time.Second
+	defaultMaxInterval     = 30 * time.Second
+	defaultMultiplier      = 2
+)
+
+var errorMessageColumns = []string{"error", "errors", "error_message", "message", "reason", "remarks"}
+
+var sourceLineColumns = []string{"line", "line_no", "line_number", "row", "row_no", "row_number"}
+
+// Wait polls job with exponential backoff until it completes or fails. A
+// failed job returns ErrJobFailed together with the final result. When the
+// job reports an error file and implements ErrorFileOpener, the file is
+// downloaded and parsed into Result.Errors.
+func Wait(ctx context.Context, job Job, opts *Options) (*Result, error) {
+	options := withDefaults(opts)
+
+	if options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.Timeout)
+		defer cancel()
+	}
+
+	started := time.Now()
+	interval := options.InitialInterval
+	result := &Result{}
+	for attempt := 1; ; attempt++ {
+		snapshot, err := job.Poll(ctx)
+		if err != nil {
+			return result, waitError(ctx, opts, err)
+		}
+		snapshot.Attempt = attempt
+		snapshot.Elapsed = time.Since(started)
+		result.Snapshot = *snapshot
+		if options.OnProgress != nil {
+			options.OnProgress(*snapshot)
+		}
+
+		if snapshot.State.Done() {
+			break
+		}
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return result, waitError(ctx, opts, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = time.Duration(float64(interval) * options.Multiplier)
+		if interval > options.MaxInterval {
+			interval = options.MaxInterval
+		}
+	}
+
+	if result.ErrorFileURL != "" && !options.SkipErrorFile {
+		if opener, ok := job.(ErrorFileOpener); ok {
+			rows, err := readErrorFile(ctx, opener, result.ErrorFileURL)
+			if err != nil {
+				return result, fmt.Errorf("read job error file: %w", err)
+			}
+			result.Errors = rows
+		}
+	}
+
+	if result.State == StateFailed {
+		if result.Message != "" {
+			return result, fmt.Errorf("%w: %s", ErrJobFailed, result.Message)
+		}
+		return result, ErrJobFailed
+	}
+
+	return result, nil
+}
+
+func withDefaults(opts *Options) Options {
+	var options Options
+	if opts != nil {
+		options = *opts
+	}
+	if options.InitialInterval <= 0 {
+		options.InitialInterval = defaultInitialInterval
+	}
+	if options.MaxInterval <= 0 {
+		options.MaxInterval = defaultMaxInterval
+	}
+	if options.MaxInterval < options.InitialInterval {
+		options.MaxInterval = options.InitialInterval
+	}
+	if options.Multiplier < 1 {
+		options.Multiplier = defaultMultiplier
+	}
+	return options
+}
+
+// waitError reports ErrTimeout when the wait's own timeout expired, while
+// keeping cancellations and deadlines from the caller's context as they are.
+func waitError(ctx context.Context, opts *Options, err error) error {
+	if opts != nil && opts.Timeout > 0 && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: %w", ErrTimeout, err)
+	}
+	return err
+}
+
+func readErrorFile(ctx context.Context, opener ErrorFileOpener, fileURL string) ([]ErrorRow, error) {
+	body, err := opener.OpenErrorFile(ctx, fileURL)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = body.Close() }()
+
+	return ParseErrorFile(body)
+}
+
+// ParseErrorFile reads a CSV error file as returned by Shiprocket bulk
+// imports. The first row is treated as the header.
+func ParseErrorFile(r io.Reader) ([]ErrorRow, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff"))
+	}
+
+	var rows []ErrorRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return rows, nil
+		}
+		if err != nil {
+			return rows, err
+		}
+
+		row := ErrorRow{Line: line, Fields: make(map[string]string, len(record))}
+		for i, value := range record {
+			if i < len(header) {
+				row.Fields[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		row.Message = firstColumn(row.Fields, errorMessageColumns)
+		if value := firstColumn(row.Fields, sourceLineColumns); value != "" {
+			row.SourceLine, _ = strconv.Atoi(value)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func firstColumn(fields map[string]string, names []string) string {
+	for _, name := range names {
+		for key, value := range fields {
+			if strings.EqualFold(strings.ReplaceAll(strings.TrimSpace(key), " ", "_"), name) && value != "" {
+				return value
+			}
+		}
+	}
+	return ""
+}
@@ -2,6 +2,7 @@ package listings
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -17,73 +18,80 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, params *ListParams, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
-	request := &internalclient.Request{Method: http.MethodGet, Path: "/v1/external/listings"}
+	request := &internalclient.Request{Operation: "listings.List", Method: http.MethodGet, Path: "/v1/external/listings"}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Link(ctx context.Context, request *LinkRequest) (*LinkResponse, error) {
+func (s *Service) Link(ctx context.Context, request *LinkRequest, opts ...internalclient.CallOption) (*LinkResponse, error) {
 	var response LinkResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/listings/link",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "listings.Link",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/listings/link",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Import(ctx context.Context, filePath string) (*ImportResponse, error) {
+func (s *Service) Import(ctx context.Context, filePath string, opts ...internalclient.CallOption) (*ImportResponse, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
+	return s.ImportFrom(ctx, filepath.Base(filePath), file, opts...)
+}
+
+// ImportFrom uploads an import file read from r under the given file name.
+func (s *Service) ImportFrom(ctx context.Context, name string, r io.Reader, opts ...internalclient.CallOption) (*ImportResponse, error) {
 	var response ImportResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/listings/import",
+		Operation: "listings.Import",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/listings/import",
 		Multipart: &internalclient.MultipartBody{
 			Files: []internalclient.MultipartFile{{
 				FieldName: "file",
-				FileName:  filepath.Base(filePath),
-				Reader:    file,
+				FileName:  name,
+				Reader:    r,
 			}},
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) ExportMapped(ctx context.Context) (*DownloadURLResponse, error) {
+func (s *Service) ExportMapped(ctx context.Context, opts ...internalclient.CallOption) (*DownloadURLResponse, error) {
 	var response DownloadURLResponse
-	if err := s.client.Do(ctx, &internalclient.Request{Method: http.MethodGet, Path: "/v1/external/listings/export/mapped"}, &response); err != nil {
+	if err := s.client.Do(ctx, &internalclient.Request{Operation: "listings.ExportMapped", Method: http.MethodGet, Path: "/v1/external/listings/export/mapped"}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) ExportUnmapped(ctx context.Context) (*DownloadURLResponse, error) {
+func (s *Service) ExportUnmapped(ctx context.Context, opts ...internalclient.CallOption) (*DownloadURLResponse, error) {
 	var response DownloadURLResponse
-	if err := s.client.Do(ctx, &internalclient.Request{Method: http.MethodGet, Path: "/v1/external/listings/export/unmapped"}, &response); err != nil {
+	if err := s.client.Do(ctx, &internalclient.Request{Operation: "listings.ExportUnmapped", Method: http.MethodGet, Path: "/v1/external/listings/export/unmapped"}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) DownloadSample(ctx context.Context) (*DownloadURLResponse, error) {
+func (s *Service) DownloadSample(ctx context.Context, opts ...internalclient.CallOption) (*DownloadURLResponse, error) {
 	var response DownloadURLResponse
-	if err := s.client.Do(ctx, &internalclient.Request{Method: http.MethodGet, Path: "/v1/external/listings/sample"}, &response); err != nil {
+	if err := s.client.Do(ctx, &internalclient.Request{Operation: "listings.DownloadSample", Method: http.MethodGet, Path: "/v1/external/listings/sample"}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
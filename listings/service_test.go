@@ -72,6 +72,10 @@ func TestListingEndpoints(t *testing.T) {
 	if err != nil || importResp.ImportID != 20294650 {
 		t.Fatalf("unexpected import response: %+v err=%v", importResp, err)
 	}
+	importResp, err = s.ImportFrom(context.Background(), "listings.csv", strings.NewReader("sku\nA1\n"))
+	if err != nil || importResp.ImportID != 20294650 {
+		t.Fatalf("unexpected ImportFrom response: %+v err=%v", importResp, err)
+	}
 	mapped, _ := s.ExportMapped(context.Background())
 	unmapped, _ := s.ExportUnmapped(context.Background())
 	sample, _ := s.DownloadSample(context.Background())
@@ -15,38 +15,41 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) ListCountries(ctx context.Context) (*CountriesResponse, error) {
+func (s *Service) ListCountries(ctx context.Context, opts ...internalclient.CallOption) (*CountriesResponse, error) {
 	var response CountriesResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/countries",
-	}, &response); err != nil {
+		Operation: "location.ListCountries",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/countries",
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) ListZones(ctx context.Context, request *ZonesRequest) (*ZonesResponse, error) {
+func (s *Service) ListZones(ctx context.Context, request *ZonesRequest, opts ...internalclient.CallOption) (*ZonesResponse, error) {
 	var response ZonesResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/countries/show/{country_id}",
+		Operation: "location.ListZones",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/countries/show/{country_id}",
 		PathParams: map[string]string{
 			"country_id": request.CountryID,
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) GetPostcodeDetails(ctx context.Context, request *PostcodeDetailsRequest) (*PostcodeDetailsResponse, error) {
+func (s *Service) GetPostcodeDetails(ctx context.Context, request *PostcodeDetailsRequest, opts ...internalclient.CallOption) (*PostcodeDetailsResponse, error) {
 	var response PostcodeDetailsResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/open/postcode/details",
-		Query:  request.QueryValues(),
-	}, &response); err != nil {
+		Operation: "location.GetPostcodeDetails",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/open/postcode/details",
+		Query:     request.QueryValues(),
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
@@ -15,44 +15,47 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, params *ListParams, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/ndr/all",
+		Operation: "ndr.List",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/ndr/all",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Get(ctx context.Context, request *GetRequest) (*ListResponse, error) {
+func (s *Service) Get(ctx context.Context, request *GetRequest, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/ndr/{awb}",
+		Operation: "ndr.Get",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/ndr/{awb}",
 		PathParams: map[string]string{
 			"awb": request.AWB,
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Act(ctx context.Context, request *ActionRequest) (*ActionResponse, error) {
+func (s *Service) Act(ctx context.Context, request *ActionRequest, opts ...internalclient.CallOption) (*ActionResponse, error) {
 	var response ActionResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
+		Operation:    "ndr.Act",
 		Method:       http.MethodPost,
 		Path:         "/v1/external/ndr/{awb}/action",
 		PathParams:   map[string]string{"awb": request.AWB},
 		JSONBody:     request.ActionPayload(),
 		ExpectedCode: []int{http.StatusOK, http.StatusAccepted},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
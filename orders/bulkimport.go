@@ -0,0 +1,495 @@
+package orders
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/internal/dates"
+)
+
+var (
+	ErrInvalidImportRows = errors.New("import rows failed validation")
+	ErrNoImportRows      = errors.New("no orders to import")
+)
+
+// ImportChecker waits for a bulk import to settle. The jobs package provides
+// one backed by the import status endpoint; see jobs.Service.OrderImportChecker.
+type ImportChecker interface {
+	WaitImport(ctx context.Context, importID int64) (*ImportStatus, error)
+}
+
+// SetImportChecker sets the checker bulk importers use when their config has
+// none. shiprocket.NewClient sets jobs.Service.OrderImportChecker; a service
+// built with NewService has no default.
+func (s *Service) SetImportChecker(checker ImportChecker) {
+	s.importChecker = checker
+}
+
+// ImportStatus is the final state of a bulk import.
+type ImportStatus struct {
+	Status  string
+	Message string
+	Failed  bool
+	Errors  []ImportErrorRow
+}
+
+// ImportErrorRow is one row of the import error file. Line counts the error
+// file's header as line 1; SourceLine is the uploaded file's line, when the
+// error file reports it.
+type ImportErrorRow struct {
+	Line       int
+	SourceLine int
+	Message    string
+	Fields     map[string]string
+}
+
+type BulkImportConfig struct {
+	// Checker waits for the import after upload. Defaults to the service's
+	// checker; see Service.SetImportChecker.
+	Checker ImportChecker
+	// NoWait returns Result.ImportID as soon as the file is uploaded,
+	// without waiting for the import. The importer also returns early when
+	// there is no checker.
+	NoWait bool
+	// FileName is the name of the uploaded file. Defaults to "orders.csv".
+	FileName string
+	// SkipInvalid uploads the valid orders when some fail validation. By
+	// default nothing is uploaded and ErrInvalidImportRows is returned.
+	SkipInvalid bool
+	// PickupLocationIDs maps pickup location names to the IDs written in the
+	// file's Location Id column; see pickupaddress.ListResponse.LocationIDs.
+	// Orders whose pickup location is not in the map fail validation.
+	PickupLocationIDs map[string]int64
+}
+
+// ImportRowError reports an order rejected locally or by Shiprocket. Line is
+// the order's position in the input: its line in a JSONL or CSV reader, or
+// its index plus one in a slice.
+type ImportRowError struct {
+	Line    int
+	OrderID string
+	Field   string
+	Message string
+}
+
+type BulkImportResult struct {
+	ImportID  int64
+	Submitted int
+	Status    *ImportStatus
+	// Invalid orders failed local validation and were not uploaded.
+	Invalid []ImportRowError
+	// Rejected orders were uploaded and refused by Shiprocket.
+	Rejected []ImportRowError
+}
+
+// BulkImporter validates orders, converts them to Shiprocket's bulk order
+// CSV and imports them in one upload.
+type BulkImporter struct {
+	service     *Service
+	config      BulkImportConfig
+	locationIDs map[string]int64
+}
+
+func (s *Service) BulkImporter(config BulkImportConfig) *BulkImporter {
+	if config.FileName == "" {
+		config.FileName = "orders.csv"
+	}
+	locationIDs := make(map[string]int64, len(config.PickupLocationIDs))
+	for name, id := range config.PickupLocationIDs {
+		locationIDs[pickupKey(name)] = id
+	}
+	return &BulkImporter{service: s, config: config, locationIDs: locationIDs}
+}
+
+func pickupKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+type importRow struct {
+	line  int
+	order *CreateCustomOrderRequest
+}
+
+func (b *BulkImporter) Import(ctx context.Context, orders []CreateCustomOrderRequest, opts ...internalclient.CallOption) (*BulkImportResult, error) {
+	rows := make([]importRow, len(orders))
+	for i := range orders {
+		rows[i] = importRow{line: i + 1, order: &orders[i]}
+	}
+	return b.run(ctx, rows, nil, opts...)
+}
+
+// ImportJSONL reads one CreateCustomOrderRequest object per line. Blank lines
+// are skipped.
+func (b *BulkImporter) ImportJSONL(ctx context.Context, r io.Reader, opts ...internalclient.CallOption) (*BulkImportResult, error) {
+	var rows []importRow
+	var invalid []ImportRowError
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		var order CreateCustomOrderRequest
+		if err := json.Unmarshal(text, &order); err != nil {
+			invalid = append(invalid, ImportRowError{Line: line, Message: err.Error()})
+			continue
+		}
+		rows = append(rows, importRow{line: line, order: &order})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return b.run(ctx, rows, invalid, opts...)
+}
+
+// ImportCSV reads orders from a CSV whose header uses the JSON field names
+// of CreateCustomOrderRequest, such as order_id and billing_pincode, plus
+// the item columns name, sku, units, selling_price, discount, tax and hsn.
+// Consecutive rows with the same order_id are items of one order.
+func (b *BulkImporter) ImportCSV(ctx context.Context, r io.Reader, opts ...internalclient.CallOption) (*BulkImportResult, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, ErrNoImportRows
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i := range header {
+		header[i] = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header[i], "\ufeff")))
+	}
+
+	var rows []importRow
+	var invalid []ImportRowError
+	var current map[string]any
+	var currentLine int
+	flush := func() {
+		if current == nil {
+			return
+		}
+		order, err := decodeCSVOrder(current)
+		if err != nil {
+			invalid = append(invalid, ImportRowError{Line: currentLine, OrderID: fmt.Sprint(current["order_id"]), Message: err.Error()})
+		} else {
+			rows = append(rows, importRow{line: currentLine, order: order})
+		}
+		current = nil
+	}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := reader.FieldPos(0)
+
+		fields := map[string]any{}
+		item := map[string]any{}
+		for i, value := range record {
+			if i >= len(header) || strings.TrimSpace(value) == "" {
+				continue
+			}
+			if csvItemColumns[header[i]] {
+				item[header[i]] = strings.TrimSpace(value)
+			} else {
+				fields[header[i]] = strings.TrimSpace(value)
+			}
+		}
+		if current == nil || fields["order_id"] == nil || fields["order_id"] != current["order_id"] {
+			flush()
+			current, currentLine = fields, line
+			current["order_items"] = []any{}
+		}
+		if len(item) > 0 {
+			current["order_items"] = append(current["order_items"].([]any), item)
+		}
+	}
+	flush()
+	return b.run(ctx, rows, invalid, opts...)
+}
+
+var csvItemColumns = map[string]bool{"name": true, "sku": true, "units": true, "selling_price": true, "discount": true, "tax": true, "hsn": true}
+
+func decodeCSVOrder(fields map[string]any) (*CreateCustomOrderRequest, error) {
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	var order CreateCustomOrderRequest
+	if err := json.Unmarshal(data, &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+func (b *BulkImporter) run(ctx context.Context, rows []importRow, invalid []ImportRowError, opts ...internalclient.CallOption) (*BulkImportResult, error) {
+	result := &BulkImportResult{Invalid: invalid}
+
+	seen := map[string]int{}
+	var valid []importRow
+	for _, row := range rows {
+		problems := ValidateImportOrder(row.order)
+		if _, ok := b.locationIDs[pickupKey(row.order.PickupLocation)]; !ok && row.order.PickupLocation != "" {
+			problems = append(problems, ImportRowError{Field: "pickup_location", Message: "has no ID in BulkImportConfig.PickupLocationIDs"})
+		}
+		if first, ok := seen[row.order.ReferenceOrderID]; ok && row.order.ReferenceOrderID != "" {
+			problems = append(problems, ImportRowError{Field: "order_id", Message: fmt.Sprintf("duplicate of the order on line %d", first)})
+		}
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				problem.Line, problem.OrderID = row.line, row.order.ReferenceOrderID
+				result.Invalid = append(result.Invalid, problem)
+			}
+			continue
+		}
+		seen[row.order.ReferenceOrderID] = row.line
+		valid = append(valid, row)
+	}
+	if len(result.Invalid) > 0 && !b.config.SkipInvalid {
+		return result, ErrInvalidImportRows
+	}
+	if len(valid) == 0 {
+		return result, ErrNoImportRows
+	}
+
+	var file bytes.Buffer
+	fileLines, err := writeImportCSV(&file, valid, b.locationIDs)
+	if err != nil {
+		return result, err
+	}
+	response, err := b.service.ImportFrom(ctx, b.config.FileName, &file, opts...)
+	if err != nil {
+		return result, err
+	}
+	result.ImportID = response.ImportID
+	result.Submitted = len(valid)
+	checker := b.config.Checker
+	if checker == nil {
+		checker = b.service.importChecker
+	}
+	if checker == nil || b.config.NoWait {
+		return result, nil
+	}
+
+	status, err := checker.WaitImport(ctx, response.ImportID)
+	if status != nil {
+		result.Status = status
+		result.Rejected = mapImportErrors(status.Errors, valid, fileLines)
+	}
+	return result, err
+}
+
+// mapImportErrors traces error file rows back to input lines, by order ID
+// when the error file carries one and by uploaded line otherwise.
+func mapImportErrors(errorRows []ImportErrorRow, rows []importRow, fileLines map[int]int) []ImportRowError {
+	byOrderID := make(map[string]int, len(rows))
+	for _, row := range rows {
+		byOrderID[row.order.ReferenceOrderID] = row.line
+	}
+	inputOrderIDs := make(map[int]string, len(rows))
+	for _, row := range rows {
+		inputOrderIDs[row.line] = row.order.ReferenceOrderID
+	}
+
+	rejected := make([]ImportRowError, 0, len(errorRows))
+	for _, row := range errorRows {
+		entry := ImportRowError{Message: row.Message}
+		for key, value := range row.Fields {
+			if normalizeColumn(key) == "order_id" {
+				entry.OrderID = value
+			}
+		}
+		if line, ok := byOrderID[entry.OrderID]; ok && entry.OrderID != "" {
+			entry.Line = line
+		} else if line, ok := fileLines[row.SourceLine]; ok {
+			entry.Line = line
+			entry.OrderID = inputOrderIDs[line]
+		}
+		rejected = append(rejected, entry)
+	}
+	return rejected
+}
+
+func normalizeColumn(name string) string {
+	name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "*")))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
+
+// ValidateImportOrder checks the fields Shiprocket requires for a bulk
+// imported order.
+func ValidateImportOrder(order *CreateCustomOrderRequest) []ImportRowError {
+	var problems []ImportRowError
+	require := func(field, value string) {
+		if strings.TrimSpace(value) == "" {
+			problems = append(problems, ImportRowError{Field: field, Message: "is required"})
+		}
+	}
+	positive := func(field string, value float64) {
+		if value <= 0 {
+			problems = append(problems, ImportRowError{Field: field, Message: "must be greater than zero"})
+		}
+	}
+
+	require("order_id", order.ReferenceOrderID)
+	require("pickup_location", order.PickupLocation)
+	if order.ChannelID.String() != "" {
+		problems = append(problems, ImportRowError{Field: "channel_id", Message: "is not supported; bulk import uses the CUSTOM channel"})
+	}
+	if _, ok := dates.Parse(order.OrderDate); !ok {
+		problems = append(problems, ImportRowError{Field: "order_date", Message: "must be a date such as 2006-01-02 15:04"})
+	}
+	switch strings.ToLower(string(order.PaymentMethod)) {
+	case "cod", "prepaid":
+	default:
+		problems = append(problems, ImportRowError{Field: "payment_method", Message: "must be COD or Prepaid"})
+	}
+
+	prefix := "billing_"
+	name, address, city, state, country, pincode, phone := order.BillingCustomerName, order.BillingAddress, order.BillingCity, order.BillingState, order.BillingCountry, order.BillingPincode, order.BillingPhone
+	if !order.ShippingIsBilling {
+		prefix = "shipping_"
+		name, address, city, state, country, pincode, phone = order.ShippingCustomerName, order.ShippingAddress, order.ShippingCity, order.ShippingState, order.ShippingCountry, order.ShippingPincode, order.ShippingPhone
+	}
+	require(prefix+"customer_name", name)
+	require(prefix+"address", address)
+	require(prefix+"city", city)
+	require(prefix+"state", state)
+	require(prefix+"country", country)
+	if country == "" || strings.EqualFold(country, "india") {
+		if len(digits(pincode)) != 6 {
+			problems = append(problems, ImportRowError{Field: prefix + "pincode", Message: "must be a 6 digit pincode"})
+		}
+	} else {
+		require(prefix+"pincode", pincode)
+	}
+	if len(mobileNumber(phone)) != 10 {
+		problems = append(problems, ImportRowError{Field: prefix + "phone", Message: "must be a 10 digit mobile number"})
+	}
+
+	if len(order.OrderItems) == 0 {
+		problems = append(problems, ImportRowError{Field: "order_items", Message: "at least one item is required"})
+	}
+	for i, item := range order.OrderItems {
+		field := fmt.Sprintf("order_items[%d].", i)
+		require(field+"name", item.Name)
+		require(field+"sku", item.Sku)
+		positive(field+"units", float64(item.Units))
+		price, err := strconv.ParseFloat(strings.TrimSpace(item.SellingPrice.String()), 64)
+		if err != nil || price <= 0 {
+			problems = append(problems, ImportRowError{Field: field + "selling_price", Message: "must be greater than zero"})
+		}
+	}
+
+	positive("length", order.Length.Float64())
+	positive("breadth", order.Breadth.Float64())
+	positive("height", order.Height.Float64())
+	positive("weight", order.Weight.Float64())
+	return problems
+}
+
+// importCSVHeader follows Shiprocket's bulk order sample file.
+var importCSVHeader = []string{
+	"*Order Id", "Order Date as dd-mm-yyyy hh:MM", "*Channel", "*Payment Method(COD/Prepaid)",
+	"*Customer First Name", "Customer Last Name", "Email (Optional)", "*Customer Mobile", "Customer Alternate Mobile",
+	"*Shipping Address Line 1", "Shipping Address Line 2", "*Shipping Address Country", "*Shipping Address State", "*Shipping Address City", "*Shipping Address Postcode",
+	"Billing Address Line 1", "Billing Address Line 2", "Billing Address Country", "Billing Address State", "Billing Address City", "Billing Address Postcode",
+	"*Master SKU", "*Product Name", "*Product Quantity", "Tax %", "*Selling Price(Per Unit Item, Inclusive of Tax)", "Discount(Per Unit Item)",
+	"Shipping Charges(Per Order)", "COD Charges(Per Order)", "Gift Wrap Charges(Per Order)", "Total Discount (Per Order)",
+	"*Length (cm)", "*Breadth (cm)", "*Height (cm)", "*Weight Of Shipment(kg)",
+	"Send Notification(True/False)", "Comment", "HSN Code", "Location Id", "Reseller Name", "Company Name", "Is documents",
+}
+
+// importChannel is the channel named in every file row. Bulk import files
+// name the channel rather than giving its ID, so orders that set channel_id
+// fail validation instead of landing in the wrong channel.
+const importChannel = "CUSTOM"
+
+// writeImportCSV writes one CSV row per order item and returns the input
+// line of each written file line, counting the header as line 1.
+func writeImportCSV(w io.Writer, rows []importRow, locationIDs map[string]int64) (map[int]int, error) {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(importCSVHeader); err != nil {
+		return nil, err
+	}
+
+	fileLines := map[int]int{}
+	fileLine := 1
+	for _, row := range rows {
+		order := row.order
+		date, _ := dates.Parse(order.OrderDate)
+		customer := [5]string{order.BillingCustomerName, order.BillingLastName, order.BillingEmail, order.BillingPhone, order.BillingAlternatePhone}
+		shipping := [6]string{order.BillingAddress, order.BillingAddress2, order.BillingCountry, order.BillingState, order.BillingCity, order.BillingPincode}
+		var billing [6]string
+		if !order.ShippingIsBilling {
+			customer = [5]string{order.ShippingCustomerName, order.ShippingLastName, order.ShippingEmail, order.ShippingPhone, order.BillingAlternatePhone}
+			shipping = [6]string{order.ShippingAddress, order.ShippingAddress2, order.ShippingCountry, order.ShippingState, order.ShippingCity, order.ShippingPincode}
+			billing = [6]string{order.BillingAddress, order.BillingAddress2, order.BillingCountry, order.BillingState, order.BillingCity, order.BillingPincode}
+		}
+
+		locationID := strconv.FormatInt(locationIDs[pickupKey(order.PickupLocation)], 10)
+
+		for _, item := range order.OrderItems {
+			record := []string{
+				order.ReferenceOrderID, date.Format("02-01-2006 15:04"), importChannel, string(order.PaymentMethod),
+				customer[0], customer[1], customer[2], mobileNumber(customer[3]), mobileNumber(customer[4]),
+			}
+			record = append(record, shipping[:]...)
+			record = append(record, billing[:]...)
+			record = append(record,
+				item.Sku, item.Name, strconv.FormatInt(int64(item.Units), 10), item.Tax.String(), item.SellingPrice.String(), item.Discount.String(),
+				formatFloat(order.ShippingCharges), "", formatFloat(order.GiftwrapCharges), formatFloat(order.TotalDiscount),
+				formatFloat(order.Length), formatFloat(order.Breadth), formatFloat(order.Height), formatFloat(order.Weight),
+				"False", order.Comment, item.HSN.String(), locationID, order.ResellerName, order.CompanyName, boolAsFlag(order.IsDocument.Bool()),
+			)
+			if err := writer.Write(record); err != nil {
+				return nil, err
+			}
+			fileLine++
+			fileLines[fileLine] = row.line
+		}
+	}
+	writer.Flush()
+	return fileLines, writer.Error()
+}
+
+func formatFloat(value FlexibleFloat) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatFloat(float64(value), 'f', -1, 64)
+}
+
+func digits(value string) string {
+	var b strings.Builder
+	for _, r := range value {
+		if r >= '0' && r <= '9' {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// mobileNumber strips formatting and the +91 prefix from an Indian number.
+func mobileNumber(value string) string {
+	number := digits(value)
+	if len(number) == 12 && strings.HasPrefix(number, "91") {
+		return number[2:]
+	}
+	if len(number) == 11 && strings.HasPrefix(number, "0") {
+		return number[1:]
+	}
+	return number
+}
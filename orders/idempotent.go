@@ -0,0 +1,175 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+)
+
+var ErrReferenceOrderIDRequired = errors.New("idempotent order creation requires a reference order ID")
+
+// IdempotencyStore remembers the response of each successful order creation,
+// keyed by channel and reference order ID. Implementations backed by a shared
+// database or cache make retries safe across processes.
+type IdempotencyStore interface {
+	Get(ctx context.Context, key string) (*CustomOrderResponse, bool, error)
+	Put(ctx context.Context, key string, response *CustomOrderResponse) error
+}
+
+type MemoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]CustomOrderResponse
+}
+
+func NewMemoryIdempotencyStore() *MemoryIdempotencyStore {
+	return &MemoryIdempotencyStore{responses: map[string]CustomOrderResponse{}}
+}
+
+func (s *MemoryIdempotencyStore) Get(_ context.Context, key string) (*CustomOrderResponse, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	response, ok := s.responses[key]
+	if !ok {
+		return nil, false, nil
+	}
+	return &response, true, nil
+}
+
+func (s *MemoryIdempotencyStore) Put(_ context.Context, key string, response *CustomOrderResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses[key] = *response
+	return nil
+}
+
+// IdempotentCreator creates custom orders at most once per reference order ID.
+// Before creating it checks the store and then Shiprocket itself, so a retry
+// after a timed out but successful attempt returns the original order.
+type IdempotentCreator struct {
+	service *Service
+	store   IdempotencyStore
+
+	mu    sync.Mutex
+	locks map[string]*keyLock
+}
+
+type keyLock struct {
+	mu   sync.Mutex
+	refs int
+}
+
+// Idempotent returns a creator that records results in store. A nil store
+// keeps results in memory for the lifetime of the creator.
+func (s *Service) Idempotent(store IdempotencyStore) *IdempotentCreator {
+	if store == nil {
+		store = NewMemoryIdempotencyStore()
+	}
+	return &IdempotentCreator{service: s, store: store, locks: map[string]*keyLock{}}
+}
+
+func (c *IdempotentCreator) CreateCustomOrder(ctx context.Context, order *CreateCustomOrderRequest, opts ...internalclient.CallOption) (*CustomOrderResponse, error) {
+	if order == nil || order.ReferenceOrderID == "" {
+		return nil, ErrReferenceOrderIDRequired
+	}
+	key := idempotencyKey(order)
+
+	unlock := c.lock(key)
+	defer unlock()
+
+	if response, ok, err := c.store.Get(ctx, key); err != nil || ok {
+		return response, err
+	}
+
+	existing, err := c.findExisting(ctx, order)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return existing, c.store.Put(ctx, key, existing)
+	}
+
+	response, err := c.service.CreateCustomOrder(ctx, order, opts...)
+	if err != nil {
+		// A transport error leaves it unknown whether the order was created,
+		// so look once more before reporting the failure.
+		var transportErr *internalclient.TransportError
+		if errors.As(err, &transportErr) && ctx.Err() == nil {
+			if existing, lookupErr := c.findExisting(ctx, order); lookupErr == nil && existing != nil {
+				return existing, c.store.Put(ctx, key, existing)
+			}
+		}
+		return nil, err
+	}
+
+	return response, c.store.Put(ctx, key, response)
+}
+
+// findExisting returns the order with the reference order ID in the order's
+// channel. Without a channel ID the order lands in a channel chosen by
+// Shiprocket, so a reference used in several channels is an error rather
+// than a guess.
+func (c *IdempotentCreator) findExisting(ctx context.Context, order *CreateCustomOrderRequest) (*CustomOrderResponse, error) {
+	channelID, _ := strconv.ParseInt(order.ChannelID.String(), 10, 64)
+	summary, err := c.service.FindByChannelOrderID(ctx, channelID, order.ReferenceOrderID)
+	if errors.Is(err, ErrOrderNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return customOrderResponseFromSummary(*summary), nil
+}
+
+func customOrderResponseFromSummary(summary OrderSummary) *CustomOrderResponse {
+	response := &CustomOrderResponse{
+		ShiprocketOrderID: summary.ID,
+		Status:            summary.Status,
+		StatusCode:        summary.StatusCode,
+	}
+	if len(summary.Shipments) > 0 {
+		shipment := summary.Shipments[0]
+		response.ShipmentID = shipment.ID
+		if shipment.AWB != "" {
+			awb := shipment.AWB
+			response.AWBCode = &awb
+		}
+		if shipment.Courier != "" {
+			courier := FlexibleString(shipment.Courier)
+			response.CourierName = &courier
+		}
+	}
+	return response
+}
+
+func idempotencyKey(order *CreateCustomOrderRequest) string {
+	if channelID := order.ChannelID.String(); channelID != "" {
+		return channelID + "/" + order.ReferenceOrderID
+	}
+	return order.ReferenceOrderID
+}
+
+// lock serialises creations of the same order within this process.
+func (c *IdempotentCreator) lock(key string) func() {
+	c.mu.Lock()
+	lock, ok := c.locks[key]
+	if !ok {
+		lock = &keyLock{}
+		c.locks[key] = lock
+	}
+	lock.refs++
+	c.mu.Unlock()
+
+	lock.mu.Lock()
+	return func() {
+		lock.mu.Unlock()
+		c.mu.Lock()
+		lock.refs--
+		if lock.refs == 0 {
+			delete(c.locks, key)
+		}
+		c.mu.Unlock()
+	}
+}
@@ -0,0 +1,356 @@
+package orders
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pagination"
+)
+
+var (
+	ErrOrderNotFound             = errors.New("order not found")
+	ErrAmbiguousChannelOrderID   = errors.New("channel order ID matches orders in several channels")
+	ErrChannelOrderIDRequired    = errors.New("channel order ID is required")
+	ErrShiprocketOrderIDRequired = errors.New("shiprocket order ID is required")
+	ErrOrderIDsRequired          = errors.New("at least one order ID is required")
+)
+
+type CloneOrderRequest struct {
+	ShiprocketOrderID int64
+	// ReferenceOrderID is the order_id of the new order. Shiprocket rejects
+	// a reference that is already in use.
+	ReferenceOrderID string
+}
+
+// OrderActionResult is the outcome of a lifecycle action on one order.
+type OrderActionResult struct {
+	ShiprocketOrderID int64           `json:"order_id"`
+	ChannelOrderID    string          `json:"channel_order_id,omitempty"`
+	Success           bool            `json:"success"`
+	Message           string          `json:"message"`
+	StatusCode        int             `json:"status_code,omitempty"`
+	Errors            json.RawMessage `json:"errors,omitempty"`
+	// Created is the new order for a successful clone.
+	Created *CustomOrderResponse `json:"-"`
+	// Err is the client-side error behind a failure, if any.
+	Err error `json:"-"`
+}
+
+type OrderActionBatchResponse []OrderActionResult
+
+func (r OrderActionBatchResponse) Successes() []OrderActionResult {
+	results := make([]OrderActionResult, 0, len(r))
+	for _, item := range r {
+		if item.Success {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
+func (r OrderActionBatchResponse) Failures() []OrderActionResult {
+	results := make([]OrderActionResult, 0, len(r))
+	for _, item := range r {
+		if !item.Success {
+			results = append(results, item)
+		}
+	}
+	return results
+}
+
+func (r OrderActionBatchResponse) HasFailures() bool {
+	return len(r.Failures()) > 0
+}
+
+// OrderActionError reports a failed single-order lifecycle action.
+type OrderActionError struct {
+	Result OrderActionResult
+}
+
+func (e *OrderActionError) Error() string {
+	if e.Result.Err != nil {
+		return fmt.Sprintf("order %d: %v", e.Result.ShiprocketOrderID, e.Result.Err)
+	}
+	return fmt.Sprintf("order %d: %s", e.Result.ShiprocketOrderID, e.Result.Message)
+}
+
+func (e *OrderActionError) Unwrap() error {
+	return e.Result.Err
+}
+
+// FindByChannelOrderID resolves a channel order ID to its Shiprocket order.
+// A zero channelID searches every channel and fails with
+// ErrAmbiguousChannelOrderID when the ID is used in more than one.
+func (s *Service) FindByChannelOrderID(ctx context.Context, channelID int64, channelOrderID string, opts ...internalclient.CallOption) (*OrderSummary, error) {
+	matches, err := s.findChannelOrders(ctx, channelID, channelOrderID, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if len(matches) == 0 {
+		return nil, ErrOrderNotFound
+	}
+	for _, match := range matches[1:] {
+		if match.ChannelID != matches[0].ChannelID {
+			return nil, ErrAmbiguousChannelOrderID
+		}
+	}
+	return &matches[0], nil
+}
+
+// channelOrderLookupPageSize is the page size used when resolving channel
+// order IDs.
+const channelOrderLookupPageSize = 100
+
+// findChannelOrders lists the orders whose channel order ID is exactly
+// channelOrderID, in the order the API returns them. The filter is a
+// partial match, so it pages through every result.
+func (s *Service) findChannelOrders(ctx context.Context, channelID int64, channelOrderID string, opts ...internalclient.CallOption) ([]OrderSummary, error) {
+	if channelOrderID == "" {
+		return nil, ErrChannelOrderIDRequired
+	}
+	pages := pagination.List(1, channelOrderLookupPageSize, func(ctx context.Context, page int) ([]OrderSummary, pagination.Meta, error) {
+		response, err := s.GetOrdersWithParams(ctx, &OrdersListParams{
+			FilterBy:  OrderFilterByChannelOrderID,
+			Filter:    channelOrderID,
+			ChannelID: channelID,
+			PerPage:   channelOrderLookupPageSize,
+			Page:      page,
+		}, opts...)
+		if err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		meta := response.Meta.Pagination
+		return response.Data, pagination.Meta{Total: meta.Total, PerPage: meta.PerPage, TotalPages: meta.TotalPages}, nil
+	})
+	orders, err := pagination.Collect(ctx, pages)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []OrderSummary
+	for _, summary := range orders {
+		if summary.ChannelOrderID == channelOrderID && (channelID == 0 || summary.ChannelID == channelID) {
+			matches = append(matches, summary)
+		}
+	}
+	return matches, nil
+}
+
+// CancelByChannelOrderID cancels the order with the given channel order ID.
+func (s *Service) CancelByChannelOrderID(ctx context.Context, channelID int64, channelOrderID string, opts ...internalclient.CallOption) error {
+	results, err := s.BulkCancelByChannelOrderIDs(ctx, channelID, []string{channelOrderID}, opts...)
+	if err != nil {
+		return err
+	}
+	return singleResult(results)
+}
+
+// BulkCancelByChannelOrderIDs resolves each channel order ID and cancels the
+// resolved orders in one request. IDs that cannot be resolved are reported
+// as failures without blocking the rest.
+func (s *Service) BulkCancelByChannelOrderIDs(ctx context.Context, channelID int64, channelOrderIDs []string, opts ...internalclient.CallOption) (OrderActionBatchResponse, error) {
+	if len(channelOrderIDs) == 0 {
+		return nil, ErrOrderIDsRequired
+	}
+
+	results := make(OrderActionBatchResponse, len(channelOrderIDs))
+	var ids []int64
+	var resolved []int
+	for i, channelOrderID := range channelOrderIDs {
+		results[i].ChannelOrderID = channelOrderID
+		summary, err := s.FindByChannelOrderID(ctx, channelID, channelOrderID, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			results[i].fail(err)
+			continue
+		}
+		results[i].ShiprocketOrderID = summary.ID
+		ids = append(ids, summary.ID)
+		resolved = append(resolved, i)
+	}
+	if len(ids) == 0 {
+		return results, nil
+	}
+
+	err := s.CancelOrders(ctx, &CancelOrdersRequest{ShiprocketOrderIDs: ids}, opts...)
+	for _, i := range resolved {
+		if err != nil {
+			results[i].fail(err)
+			continue
+		}
+		results[i].Success = true
+		results[i].Message = "Order cancelled"
+	}
+	return results, nil
+}
+
+// CloneOrder creates a copy of an existing order under a new reference
+// order ID, for example to re-ship a lost parcel. Use CloneRequest to change
+// the copy before creating it.
+func (s *Service) CloneOrder(ctx context.Context, orderID int64, referenceOrderID string, opts ...internalclient.CallOption) (*CustomOrderResponse, error) {
+	if orderID == 0 {
+		return nil, ErrShiprocketOrderIDRequired
+	}
+	if referenceOrderID == "" {
+		return nil, ErrReferenceOrderIDRequired
+	}
+	detail, err := s.GetOrderDetails(ctx, &GetOrderDetailsRequest{ShiprocketOrderID: orderID}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return s.CreateCustomOrder(ctx, CloneRequest(detail.Data, referenceOrderID), opts...)
+}
+
+// BulkCloneOrders clones each order in turn and reports every outcome.
+func (s *Service) BulkCloneOrders(ctx context.Context, clones []CloneOrderRequest, opts ...internalclient.CallOption) (OrderActionBatchResponse, error) {
+	if len(clones) == 0 {
+		return nil, ErrOrderIDsRequired
+	}
+
+	results := make(OrderActionBatchResponse, len(clones))
+	for i, clone := range clones {
+		results[i].ShiprocketOrderID = clone.ShiprocketOrderID
+		results[i].ChannelOrderID = clone.ReferenceOrderID
+		created, err := s.CloneOrder(ctx, clone.ShiprocketOrderID, clone.ReferenceOrderID, opts...)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			results[i].fail(err)
+			continue
+		}
+		results[i].Success = true
+		results[i].Message = created.Status
+		results[i].Created = created
+	}
+	return results, nil
+}
+
+// CloneRequest builds a custom order request that reproduces detail under a
+// new reference order ID, dated now.
+func CloneRequest(detail OrderDetail, referenceOrderID string) *CreateCustomOrderRequest {
+	fields := OrderRequestFields{
+		ReferenceOrderID:      referenceOrderID,
+		OrderDate:             time.Now().Format("2006-01-02 15:04"),
+		PickupLocation:        detail.PickupLocation,
+		ResellerName:          detail.ResellerName,
+		CompanyName:           detail.CompanyName,
+		BillingCustomerName:   detail.BillingName,
+		BillingAddress:        detail.BillingAddress,
+		BillingAddress2:       detail.BillingAddress2,
+		BillingCity:           detail.BillingCity,
+		BillingPincode:        detail.BillingPincode,
+		BillingState:          detail.BillingStateName,
+		BillingCountry:        detail.BillingCountryName,
+		BillingEmail:          detail.BillingEmail,
+		BillingPhone:          detail.BillingPhone,
+		BillingAlternatePhone: detail.BillingAlternatePhone,
+		ShippingIsBilling:     FlexibleBool(detail.ShippingIsBilling != 0),
+		IsDocument:            FlexibleBool(detail.IsDocument != 0),
+		PaymentMethod:         detail.PaymentMethod,
+		GiftwrapCharges:       FlexibleFloat(parseFloat(detail.GiftwrapCharges.String())),
+		Length:                detail.Shipments.Length,
+		Breadth:               detail.Shipments.Breadth,
+		Height:                detail.Shipments.Height,
+		Weight:                detail.Shipments.Weight,
+	}
+	if detail.ChannelID > 0 {
+		fields.ChannelID = FlexibleString(strconv.FormatInt(detail.ChannelID, 10))
+	}
+
+	// The customer fields hold the delivery address.
+	if fields.BillingCustomerName == "" || fields.BillingAddress == "" {
+		fields.BillingCustomerName = detail.CustomerName
+		fields.BillingAddress = detail.CustomerAddress
+		fields.BillingAddress2 = stringValue(detail.CustomerAddress2)
+		fields.BillingCity = detail.CustomerCity
+		fields.BillingPincode = detail.CustomerPincode
+		fields.BillingState = detail.CustomerState
+		fields.BillingCountry = detail.CustomerCountry
+		fields.BillingEmail = detail.CustomerEmail
+		fields.BillingPhone = detail.CustomerPhone
+		fields.ShippingIsBilling = true
+	}
+	if !fields.ShippingIsBilling {
+		fields.ShippingCustomerName = detail.CustomerName
+		fields.ShippingAddress = detail.CustomerAddress
+		fields.ShippingAddress2 = stringValue(detail.CustomerAddress2)
+		fields.ShippingCity = detail.CustomerCity
+		fields.ShippingPincode = detail.CustomerPincode
+		fields.ShippingState = detail.CustomerState
+		fields.ShippingCountry = detail.CustomerCountry
+		fields.ShippingEmail = detail.CustomerEmail
+		fields.ShippingPhone = detail.CustomerPhone
+	}
+
+	var subTotal float64
+	for _, product := range detail.Products {
+		price := product.SellingPrice.Float64()
+		if price == 0 {
+			price = product.Price.Float64()
+		}
+		subTotal += price * float64(product.Quantity)
+		fields.OrderItems = append(fields.OrderItems, OrderItem{
+			Name:         product.Name,
+			Sku:          product.SKU,
+			Units:        product.Quantity,
+			SellingPrice: FlexibleString(strconv.FormatFloat(price, 'f', -1, 64)),
+			Discount:     formatNonZero(product.Discount.Float64()),
+			Tax:          formatNonZero(product.TaxPercentage.Float64()),
+			HSN:          product.HSN,
+		})
+	}
+	fields.SubTotal = FlexibleFloat(subTotal)
+	return &CreateCustomOrderRequest{OrderRequestFields: fields}
+}
+
+func (r *OrderActionResult) fail(err error) {
+	r.Success = false
+	r.Err = err
+	r.Message = err.Error()
+	r.StatusCode = apiStatusCode(err)
+}
+
+func apiStatusCode(err error) int {
+	var apiErr *internalclient.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Meta.StatusCode
+	}
+	return 0
+}
+
+func singleResult(results OrderActionBatchResponse) error {
+	for _, result := range results {
+		if !result.Success {
+			return &OrderActionError{Result: result}
+		}
+	}
+	return nil
+}
+
+func parseFloat(value string) float64 {
+	parsed, _ := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return parsed
+}
+
+func formatNonZero(value float64) FlexibleString {
+	if value == 0 {
+		return ""
+	}
+	return FlexibleString(strconv.FormatFloat(value, 'f', -1, 64))
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
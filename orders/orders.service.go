@@ -3,6 +3,7 @@ package orders
 import (
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -12,7 +13,8 @@ import (
 )
 
 type Service struct {
-	client *internalclient.Client
+	client        *internalclient.Client
+	importChecker ImportChecker
 }
 
 type OrderService struct {
@@ -37,13 +39,14 @@ func (o *OrderService) CreateCustomOrderContext(ctx context.Context, order *Orde
 	return NewService(o.client()).CreateCustomOrder(ctx, &CreateCustomOrderRequest{OrderRequestFields: *order})
 }
 
-func (s *Service) CreateCustomOrder(ctx context.Context, order *CreateCustomOrderRequest) (*CustomOrderResponse, error) {
+func (s *Service) CreateCustomOrder(ctx context.Context, order *CreateCustomOrderRequest, opts ...internalclient.CallOption) (*CustomOrderResponse, error) {
 	var response CustomOrderResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/create/adhoc",
-		JSONBody: order,
-	}, &response)
+		Operation: "orders.CreateCustomOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/create/adhoc",
+		JSONBody:  order,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -57,13 +60,14 @@ func (o *OrderService) CreateChannelSpecificOrder(order *Order) (*ChannelSpecifi
 	return NewService(o.client()).CreateChannelSpecificOrder(context.Background(), &CreateChannelSpecificOrderRequest{OrderRequestFields: *order})
 }
 
-func (s *Service) CreateChannelSpecificOrder(ctx context.Context, order *CreateChannelSpecificOrderRequest) (*ChannelSpecificOrderResponse, error) {
+func (s *Service) CreateChannelSpecificOrder(ctx context.Context, order *CreateChannelSpecificOrderRequest, opts ...internalclient.CallOption) (*ChannelSpecificOrderResponse, error) {
 	var response ChannelSpecificOrderResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/create",
-		JSONBody: order,
-	}, &response)
+		Operation: "orders.CreateChannelSpecificOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/create",
+		JSONBody:  order,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -76,13 +80,14 @@ func (o *OrderService) UpdatePickupLocation(update *UpdatePickupLocationRequest)
 	return NewService(o.client()).UpdatePickupLocation(context.Background(), update)
 }
 
-func (s *Service) UpdatePickupLocation(ctx context.Context, update *UpdatePickupLocationRequest) (*UpdatePickupLocationResponse, error) {
+func (s *Service) UpdatePickupLocation(ctx context.Context, update *UpdatePickupLocationRequest, opts ...internalclient.CallOption) (*UpdatePickupLocationResponse, error) {
 	var response UpdatePickupLocationResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPatch,
-		Path:     "/v1/external/orders/address/pickup",
-		JSONBody: update,
-	}, &response)
+		Operation: "orders.UpdatePickupLocation",
+		Method:    http.MethodPatch,
+		Path:      "/v1/external/orders/address/pickup",
+		JSONBody:  update,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -95,13 +100,14 @@ func (o *OrderService) UpdateCustomerDeliveryAddress(update *UpdateCustomerDeliv
 	return NewService(o.client()).UpdateCustomerDeliveryAddress(context.Background(), update)
 }
 
-func (s *Service) UpdateCustomerDeliveryAddress(ctx context.Context, update *UpdateCustomerDeliveryAddressRequest) (*UpdateCustomerDeliveryAddressResponse, error) {
+func (s *Service) UpdateCustomerDeliveryAddress(ctx context.Context, update *UpdateCustomerDeliveryAddressRequest, opts ...internalclient.CallOption) (*UpdateCustomerDeliveryAddressResponse, error) {
 	var response UpdateCustomerDeliveryAddressResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/address/update",
-		JSONBody: update,
-	}, &response)
+		Operation: "orders.UpdateCustomerDeliveryAddress",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/address/update",
+		JSONBody:  update,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -114,13 +120,14 @@ func (o *OrderService) UpdateOrder(orderUpdate *Order) (*OrderUpdateResponse, er
 	return NewService(o.client()).UpdateOrder(context.Background(), &UpdateOrderRequest{OrderRequestFields: *orderUpdate})
 }
 
-func (s *Service) UpdateOrder(ctx context.Context, orderUpdate *UpdateOrderRequest) (*OrderUpdateResponse, error) {
+func (s *Service) UpdateOrder(ctx context.Context, orderUpdate *UpdateOrderRequest, opts ...internalclient.CallOption) (*OrderUpdateResponse, error) {
 	var response OrderUpdateResponse
 	err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/update/adhoc",
-		JSONBody: orderUpdate,
-	}, &response)
+		Operation: "orders.UpdateOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/update/adhoc",
+		JSONBody:  orderUpdate,
+	}, &response, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -133,13 +140,14 @@ func (o *OrderService) CancelOrders(orderCancel *CancelOrdersRequest) error {
 	return NewService(o.client()).CancelOrders(context.Background(), orderCancel)
 }
 
-func (s *Service) CancelOrders(ctx context.Context, orderCancel *CancelOrdersRequest) error {
+func (s *Service) CancelOrders(ctx context.Context, orderCancel *CancelOrdersRequest, opts ...internalclient.CallOption) error {
 	return s.client.Do(ctx, &internalclient.Request{
+		Operation:    "orders.CancelOrders",
 		Method:       http.MethodPost,
 		Path:         "/v1/external/orders/cancel",
 		JSONBody:     orderCancel,
 		ExpectedCode: []int{http.StatusOK, http.StatusAccepted, http.StatusNoContent},
-	}, nil)
+	}, nil, opts...)
 }
 
 // Add Inventory for Ordered Product
@@ -147,13 +155,14 @@ func (o *OrderService) AddInventoryForOrderedProduct(orderFulfill *FulfillOrderI
 	return NewService(o.client()).AddInventoryForOrderedProduct(context.Background(), orderFulfill)
 }
 
-func (s *Service) AddInventoryForOrderedProduct(ctx context.Context, orderFulfill *FulfillOrderItemsRequest) (FulfillmentBatchResponse, error) {
+func (s *Service) AddInventoryForOrderedProduct(ctx context.Context, orderFulfill *FulfillOrderItemsRequest, opts ...internalclient.CallOption) (FulfillmentBatchResponse, error) {
 	var fulfillResponses FulfillmentBatchResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPatch,
-		Path:     "/v1/external/orders/fulfill",
-		JSONBody: orderFulfill,
-	}, &fulfillResponses); err != nil {
+		Operation: "orders.AddInventoryForOrderedProduct",
+		Method:    http.MethodPatch,
+		Path:      "/v1/external/orders/fulfill",
+		JSONBody:  orderFulfill,
+	}, &fulfillResponses, opts...); err != nil {
 		return nil, err
 	}
 
@@ -165,13 +174,14 @@ func (o *OrderService) MapOrders(orderMapping *MapUnmappedProductsRequest) (Mapp
 	return NewService(o.client()).MapOrders(context.Background(), orderMapping)
 }
 
-func (s *Service) MapOrders(ctx context.Context, orderMapping *MapUnmappedProductsRequest) (MappingBatchResponse, error) {
+func (s *Service) MapOrders(ctx context.Context, orderMapping *MapUnmappedProductsRequest, opts ...internalclient.CallOption) (MappingBatchResponse, error) {
 	var mappingResponses MappingBatchResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPatch,
-		Path:     "/v1/external/orders/mapping",
-		JSONBody: orderMapping,
-	}, &mappingResponses); err != nil {
+		Operation: "orders.MapOrders",
+		Method:    http.MethodPatch,
+		Path:      "/v1/external/orders/mapping",
+		JSONBody:  orderMapping,
+	}, &mappingResponses, opts...); err != nil {
 		return nil, err
 	}
 
@@ -183,27 +193,34 @@ func (o *OrderService) ImportOrders(filePath string) (*ImportOrdersResponse, err
 	return NewService(o.client()).ImportOrders(context.Background(), filePath)
 }
 
-func (s *Service) ImportOrders(ctx context.Context, filePath string) (*ImportOrdersResponse, error) {
+func (s *Service) ImportOrders(ctx context.Context, filePath string, opts ...internalclient.CallOption) (*ImportOrdersResponse, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
+	return s.ImportFrom(ctx, filepath.Base(filePath), file, opts...)
+}
+
+// ImportFrom uploads a bulk order file read from r. name is the file name
+// sent to Shiprocket, which uses its extension to detect the format.
+func (s *Service) ImportFrom(ctx context.Context, name string, r io.Reader, opts ...internalclient.CallOption) (*ImportOrdersResponse, error) {
 	var importResponse ImportOrdersResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/orders/import",
+		Operation: "orders.ImportOrders",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/import",
 		Multipart: &internalclient.MultipartBody{
 			Files: []internalclient.MultipartFile{
 				{
 					FieldName: "file",
-					FileName:  filepath.Base(filePath),
-					Reader:    file,
+					FileName:  name,
+					Reader:    r,
 				},
 			},
 		},
-	}, &importResponse); err != nil {
+	}, &importResponse, opts...); err != nil {
 		return nil, err
 	}
 
@@ -215,24 +232,25 @@ func (o *OrderService) GetOrders() (*OrdersListResponse, error) {
 	return NewService(o.client()).GetOrders(context.Background())
 }
 
-func (s *Service) GetOrders(ctx context.Context) (*OrdersListResponse, error) {
-	return s.GetOrdersWithParams(ctx, nil)
+func (s *Service) GetOrders(ctx context.Context, opts ...internalclient.CallOption) (*OrdersListResponse, error) {
+	return s.GetOrdersWithParams(ctx, nil, opts...)
 }
 
 func (o *OrderService) GetOrdersWithParams(params *OrdersListParams) (*OrdersListResponse, error) {
 	return NewService(o.client()).GetOrdersWithParams(context.Background(), params)
 }
 
-func (s *Service) GetOrdersWithParams(ctx context.Context, params *OrdersListParams) (*OrdersListResponse, error) {
+func (s *Service) GetOrdersWithParams(ctx context.Context, params *OrdersListParams, opts ...internalclient.CallOption) (*OrdersListResponse, error) {
 	var ordersResponse OrdersListResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/orders",
+		Operation: "orders.GetOrdersWithParams",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/orders",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &ordersResponse); err != nil {
+	if err := s.client.Do(ctx, request, &ordersResponse, opts...); err != nil {
 		return nil, err
 	}
 
@@ -244,7 +262,7 @@ func (o *OrderService) GetOrderByID(orderID string) (OrderDetailResponse, error)
 	return NewService(o.client()).GetOrderByID(context.Background(), orderID)
 }
 
-func (s *Service) GetOrderByID(ctx context.Context, orderID string) (OrderDetailResponse, error) {
+func (s *Service) GetOrderByID(ctx context.Context, orderID string, opts ...internalclient.CallOption) (OrderDetailResponse, error) {
 	parsed, err := strconv.ParseInt(orderID, 10, 64)
 	if err != nil {
 		return OrderDetailResponse{}, &internalclient.TransportError{
@@ -256,14 +274,14 @@ func (s *Service) GetOrderByID(ctx context.Context, orderID string) (OrderDetail
 
 	return s.GetOrderDetails(ctx, &GetOrderDetailsRequest{
 		ShiprocketOrderID: parsed,
-	})
+	}, opts...)
 }
 
 func (o *OrderService) GetOrderDetails(request *GetOrderDetailsRequest) (OrderDetailResponse, error) {
 	return NewService(o.client()).GetOrderDetails(context.Background(), request)
 }
 
-func (s *Service) GetOrderDetails(ctx context.Context, request *GetOrderDetailsRequest) (OrderDetailResponse, error) {
+func (s *Service) GetOrderDetails(ctx context.Context, request *GetOrderDetailsRequest, opts ...internalclient.CallOption) (OrderDetailResponse, error) {
 	var response OrderDetailResponse
 	if request == nil {
 		return OrderDetailResponse{}, &internalclient.TransportError{
@@ -273,10 +291,11 @@ func (s *Service) GetOrderDetails(ctx context.Context, request *GetOrderDetailsR
 		}
 	}
 	err := s.client.Do(ctx, &internalclient.Request{
+		Operation:  "orders.GetOrderDetails",
 		Method:     http.MethodGet,
 		Path:       "/v1/external/orders/show/{order_id}",
 		PathParams: map[string]string{"order_id": formatShiprocketOrderID(request.ShiprocketOrderID)},
-	}, &response)
+	}, &response, opts...)
 	if err != nil {
 		return OrderDetailResponse{}, err
 	}
@@ -288,17 +307,18 @@ func (o *OrderService) ExportOrders() (*ExportOrdersResponse, error) {
 	return NewService(o.client()).ExportOrders(context.Background(), &ExportOrdersRequest{})
 }
 
-func (s *Service) ExportOrders(ctx context.Context, request *ExportOrdersRequest) (*ExportOrdersResponse, error) {
+func (s *Service) ExportOrders(ctx context.Context, request *ExportOrdersRequest, opts ...internalclient.CallOption) (*ExportOrdersResponse, error) {
 	if request == nil {
 		request = &ExportOrdersRequest{}
 	}
 
 	var response ExportOrdersResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/export",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "orders.ExportOrders",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/export",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
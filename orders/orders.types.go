@@ -414,6 +414,10 @@ type OrderSummary struct {
 	CustomerName      string                 `json:"customer_name"`
 	CustomerEmail     string                 `json:"customer_email"`
 	CustomerPhone     string                 `json:"customer_phone"`
+	CustomerCity      string                 `json:"customer_city,omitempty"`
+	CustomerState     string                 `json:"customer_state,omitempty"`
+	CustomerPincode   FlexibleString         `json:"customer_pincode,omitempty"`
+	CustomerCountry   string                 `json:"customer_country,omitempty"`
 	PickupLocation    string                 `json:"pickup_location"`
 	PaymentStatus     string                 `json:"payment_status"`
 	Total             FlexibleString         `json:"total"`
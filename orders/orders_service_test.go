@@ -2,6 +2,7 @@ package orders
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"io"
@@ -10,7 +11,9 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
 )
@@ -551,3 +554,416 @@ func compactJSON(t *testing.T, payload string) string {
 	}
 	return string(encoded)
 }
+
+func TestIdempotentCreateCustomOrder(t *testing.T) {
+	newOrder := func(ref string) *CreateCustomOrderRequest {
+		return &CreateCustomOrderRequest{OrderRequestFields: OrderRequestFields{ReferenceOrderID: ref, PickupLocation: "Primary"}}
+	}
+
+	t.Run("creates once and replays the stored response", func(t *testing.T) {
+		var creates, lookups atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/external/orders":
+				lookups.Add(1)
+				if r.URL.Query().Get("filter_by") != string(OrderFilterByChannelOrderID) || r.URL.Query().Get("filter") != "ref-1" {
+					t.Fatalf("unexpected lookup query %q", r.URL.RawQuery)
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":1,"channel_order_id":"ref-10"}]}`))
+			case "/v1/external/orders/create/adhoc":
+				creates.Add(1)
+				_, _ = w.Write([]byte(`{"order_id":555,"shipment_id":777,"status":"NEW","status_code":1}`))
+			default:
+				t.Fatalf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		creator := NewService(internalclient.New(server.URL, internalclient.WithToken("token"))).Idempotent(nil)
+		for i := 0; i < 3; i++ {
+			response, err := creator.CreateCustomOrder(context.Background(), newOrder("ref-1"))
+			if err != nil {
+				t.Fatalf("CreateCustomOrder returned error: %v", err)
+			}
+			if response.ShiprocketOrderID != 555 || response.ShipmentID != 777 {
+				t.Fatalf("unexpected response %#v", response)
+			}
+		}
+		if creates.Load() != 1 || lookups.Load() != 1 {
+			t.Fatalf("expected one lookup and one create, got %d and %d", lookups.Load(), creates.Load())
+		}
+	})
+
+	t.Run("returns an order that already exists", func(t *testing.T) {
+		store := NewMemoryIdempotencyStore()
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/external/orders" {
+				t.Fatalf("unexpected request to %s", r.URL.Path)
+			}
+			if r.URL.Query().Get("channel_id") != "443555" {
+				t.Fatalf("expected channel filter, got %q", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":42,"channel_id":443555,"channel_order_id":"ref-2","status":"NEW","status_code":1,"shipments":[{"id":99,"awb":"AWB1","courier":"Bluedart"}]}]}`))
+		}))
+		defer server.Close()
+
+		order := newOrder("ref-2")
+		order.ChannelID = "443555"
+		response, err := NewService(internalclient.New(server.URL, internalclient.WithToken("token"))).Idempotent(store).CreateCustomOrder(context.Background(), order)
+		if err != nil {
+			t.Fatalf("CreateCustomOrder returned error: %v", err)
+		}
+		if response.ShiprocketOrderID != 42 || response.ShipmentID != 99 || response.AWBCode == nil || *response.AWBCode != "AWB1" {
+			t.Fatalf("unexpected response %#v", response)
+		}
+		if stored, ok, _ := store.Get(context.Background(), "443555/ref-2"); !ok || stored.ShiprocketOrderID != 42 {
+			t.Fatalf("expected response to be stored, got %#v", stored)
+		}
+	})
+
+	t.Run("refuses to guess when the reference exists in several channels", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/v1/external/orders" {
+				t.Fatalf("unexpected request to %s", r.URL.Path)
+			}
+			_, _ = w.Write([]byte(`{"data":[{"id":51,"channel_id":1,"channel_order_id":"ref-4","status":"NEW"},{"id":52,"channel_id":2,"channel_order_id":"ref-4","status":"NEW"}]}`))
+		}))
+		defer server.Close()
+
+		_, err := NewService(internalclient.New(server.URL, internalclient.WithToken("token"))).Idempotent(nil).CreateCustomOrder(context.Background(), newOrder("ref-4"))
+		if !errors.Is(err, ErrAmbiguousChannelOrderID) {
+			t.Fatalf("expected ErrAmbiguousChannelOrderID, got %v", err)
+		}
+	})
+
+	t.Run("recovers the order after a transport error", func(t *testing.T) {
+		var created atomic.Bool
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/external/orders":
+				if !created.Load() {
+					_, _ = w.Write([]byte(`{"data":[]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":7,"channel_order_id":"ref-3","status":"NEW"}]}`))
+			case "/v1/external/orders/create/adhoc":
+				created.Store(true)
+				conn, _, err := w.(http.Hijacker).Hijack()
+				if err != nil {
+					t.Fatalf("hijack: %v", err)
+				}
+				_ = conn.Close()
+			}
+		}))
+		defer server.Close()
+
+		response, err := NewService(internalclient.New(server.URL, internalclient.WithToken("token"))).Idempotent(nil).CreateCustomOrder(context.Background(), newOrder("ref-3"))
+		if err != nil {
+			t.Fatalf("CreateCustomOrder returned error: %v", err)
+		}
+		if response.ShiprocketOrderID != 7 {
+			t.Fatalf("unexpected response %#v", response)
+		}
+	})
+
+	t.Run("requires a reference order ID", func(t *testing.T) {
+		creator := NewService(internalclient.New("https://example.com", internalclient.WithToken("token"))).Idempotent(nil)
+		if _, err := creator.CreateCustomOrder(context.Background(), newOrder("")); !errors.Is(err, ErrReferenceOrderIDRequired) {
+			t.Fatalf("expected ErrReferenceOrderIDRequired, got %v", err)
+		}
+	})
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	var cancelled []int64
+	var created CreateCustomOrderRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/orders":
+			switch r.URL.Query().Get("filter") {
+			case "ref-1":
+				_, _ = w.Write([]byte(`{"data":[{"id":101,"channel_id":7,"channel_order_id":"ref-1"}]}`))
+			case "ref-2":
+				if r.URL.Query().Get("page") == "2" {
+					_, _ = w.Write([]byte(`{"data":[{"id":402,"channel_id":7,"channel_order_id":"ref-2"}],"meta":{"pagination":{"total":2,"per_page":1,"current_page":2,"total_pages":2}}}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"data":[{"id":401,"channel_id":7,"channel_order_id":"ref-20"}],"meta":{"pagination":{"total":2,"per_page":1,"current_page":1,"total_pages":2}}}`))
+			case "ref-dup":
+				_, _ = w.Write([]byte(`{"data":[{"id":201,"channel_id":7,"channel_order_id":"ref-dup"},{"id":202,"channel_id":8,"channel_order_id":"ref-dup"}]}`))
+			default:
+				_, _ = w.Write([]byte(`{"data":[{"id":301,"channel_id":7,"channel_order_id":"ref-10"}]}`))
+			}
+		case "/v1/external/orders/cancel":
+			var body CancelOrdersRequest
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			cancelled = append(cancelled, body.ShiprocketOrderIDs...)
+			w.WriteHeader(http.StatusNoContent)
+		case "/v1/external/orders/show/101":
+			_, _ = w.Write([]byte(`{"data":{"id":101,"channel_id":7,"channel_order_id":"ref-1","customer_name":"Naruto","customer_address":"Leaf Village","customer_city":"Konoha","customer_state":"Fire","customer_pincode":"110001","customer_country":"India","customer_phone":"9999999999","pickup_location":"Home","payment_method":"prepaid","products":[{"name":"Ramen","sku":"RMN-1","quantity":2,"selling_price":"150.5","tax_percentage":"5"}],"shipments":{"length":10,"breadth":8,"height":4,"weight":0.5}}}`))
+		case "/v1/external/orders/show/404":
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"Order not found","status_code":404}`))
+		case "/v1/external/orders/create/adhoc":
+			_ = json.NewDecoder(r.Body).Decode(&created)
+			_, _ = w.Write([]byte(`{"order_id":102,"shipment_id":55,"status":"NEW","status_code":1}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	ctx := context.Background()
+
+	if err := s.CancelByChannelOrderID(ctx, 7, "ref-1"); err != nil {
+		t.Fatalf("CancelByChannelOrderID returned error: %v", err)
+	}
+	if found, err := s.FindByChannelOrderID(ctx, 7, "ref-2"); err != nil || found.ID != 402 {
+		t.Fatalf("expected the match from the second page, got %+v err=%v", found, err)
+	}
+	results, err := s.BulkCancelByChannelOrderIDs(ctx, 0, []string{"ref-1", "ref-dup", "ref-missing"})
+	if err != nil {
+		t.Fatalf("BulkCancelByChannelOrderIDs returned error: %v", err)
+	}
+	if len(results.Successes()) != 1 || results[0].ShiprocketOrderID != 101 {
+		t.Fatalf("unexpected cancel results: %+v", results)
+	}
+	if !errors.Is(results[1].Err, ErrAmbiguousChannelOrderID) || !errors.Is(results[2].Err, ErrOrderNotFound) {
+		t.Fatalf("unexpected cancel failures: %+v", results.Failures())
+	}
+	if len(cancelled) != 2 || cancelled[0] != 101 || cancelled[1] != 101 {
+		t.Fatalf("unexpected cancelled IDs: %v", cancelled)
+	}
+	var actionErr *OrderActionError
+	if err := s.CancelByChannelOrderID(ctx, 7, "ref-missing"); !errors.As(err, &actionErr) || !errors.Is(err, ErrOrderNotFound) {
+		t.Fatalf("expected OrderActionError wrapping ErrOrderNotFound, got %v", err)
+	}
+
+	clone, err := s.CloneOrder(ctx, 101, "ref-1-reship")
+	if err != nil || clone.ShiprocketOrderID != 102 {
+		t.Fatalf("unexpected clone response: %+v err=%v", clone, err)
+	}
+	if created.ReferenceOrderID != "ref-1-reship" || created.ChannelID != "7" || created.BillingCustomerName != "Naruto" || !created.ShippingIsBilling.Bool() {
+		t.Fatalf("unexpected clone request: %+v", created.OrderRequestFields)
+	}
+	if len(created.OrderItems) != 1 || created.OrderItems[0].SellingPrice != "150.5" || created.OrderItems[0].Tax != "5" || created.SubTotal != 301 || created.Weight != 0.5 {
+		t.Fatalf("unexpected cloned items: %+v sub_total=%v", created.OrderItems, created.SubTotal)
+	}
+	clones, err := s.BulkCloneOrders(ctx, []CloneOrderRequest{{ShiprocketOrderID: 101, ReferenceOrderID: "ref-1-b"}, {ShiprocketOrderID: 404, ReferenceOrderID: "ref-4-b"}})
+	if err != nil || len(clones.Successes()) != 1 || clones[0].Created.ShiprocketOrderID != 102 || clones[1].StatusCode != http.StatusNotFound {
+		t.Fatalf("unexpected bulk clone results: %+v err=%v", clones, err)
+	}
+}
+
+func TestOrderQuery(t *testing.T) {
+	ist := time.FixedZone("IST", 5*60*60+30*60)
+	from := time.Date(2026, 10, 18, 0, 0, 0, 0, ist)
+	to := from.AddDate(0, 0, 1)
+
+	var pages int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/external/orders" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("filter_by") != "status" || query.Get("filter") != "NEW" || query.Get("from") != "2026-10-18" || query.Get("to") != "2026-10-18" || query.Get("channel_id") != "" {
+			t.Fatalf("unexpected query: %s", r.URL.RawQuery)
+		}
+		atomic.AddInt32(&pages, 1)
+		switch query.Get("page") {
+		case "1":
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":1,"channel_id":7,"status":"NEW","payment_method":"cod","customer_state":"Maharashtra","customer_pincode":"400001","created_at":"18 Oct 2026, 09:15 AM","products":[{"channel_sku":"RMN-1"}]},
+				{"id":2,"channel_id":7,"status":"NEW","payment_method":"prepaid","customer_state":"Maharashtra","created_at":"18 Oct 2026, 10:00 AM"},
+				{"id":3,"channel_id":9,"status":"NEW","payment_method":"cod","customer_state":"Maharashtra","created_at":"18 Oct 2026, 11:00 AM"}
+			],"meta":{"pagination":{"total":5,"per_page":3,"current_page":1,"total_pages":2}}}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":[
+				{"id":4,"channel_id":8,"status":"NEW","payment_method":"COD","customer_state":"maharashtra","customer_pincode":400002,"created_at":"18 Oct 2026, 11:59 PM","products":[{"channel_sku":"rmn-1"}]},
+				{"id":5,"channel_id":7,"status":"NEW","payment_method":"cod","customer_state":"Karnataka","created_at":"19 Oct 2026, 12:01 AM"}
+			],"meta":{"pagination":{"total":5,"per_page":3,"current_page":2,"total_pages":2}}}`))
+		}
+	}))
+	defer server.Close()
+
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	q := NewQuery().
+		Status("NEW").
+		PaymentMethod(PaymentMethodCOD).
+		State("Maharashtra").
+		CreatedBetween(from, to).
+		Channel(7, 8).
+		SKU("RMN-1")
+	it := s.Query(q)
+	var ids []int64
+	for it.Next(context.Background()) {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Fatalf("Query returned error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 4 {
+		t.Fatalf("unexpected matches: %v", ids)
+	}
+	if atomic.LoadInt32(&pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", pages)
+	}
+
+	params := NewQuery().Status("NEW", "READY TO SHIP").PaymentMethod(PaymentMethodPrepaid).Channel(7).Params()
+	if params.FilterBy != OrderFilterByPaymentMethod || params.Filter != "Prepaid" || params.ChannelID != 7 {
+		t.Fatalf("unexpected pushed params: %+v", params)
+	}
+	if !NewQuery().Pincode("400001").Match(OrderSummary{CustomerPincode: "400001"}) || NewQuery().Pincode("400001").Match(OrderSummary{}) {
+		t.Fatal("unexpected pincode match")
+	}
+	if !NewQuery().PickupLocation("primary").Match(OrderSummary{PickupLocation: "Primary"}) || NewQuery().PickupLocation("Primary").Match(OrderSummary{}) {
+		t.Fatal("unexpected pickup location match")
+	}
+}
+
+type fakeImportChecker struct {
+	importID int64
+	status   *ImportStatus
+}
+
+func (c *fakeImportChecker) WaitImport(_ context.Context, importID int64) (*ImportStatus, error) {
+	c.importID = importID
+	return c.status, nil
+}
+
+func TestBulkImporter(t *testing.T) {
+	var uploaded [][]string
+	var uploads int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/external/orders/import" {
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+		atomic.AddInt32(&uploads, 1)
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			t.Fatalf("FormFile returned error: %v", err)
+		}
+		if header.Filename != "orders.csv" {
+			t.Fatalf("unexpected filename: %s", header.Filename)
+		}
+		uploaded, err = csv.NewReader(file).ReadAll()
+		if err != nil {
+			t.Fatalf("uploaded CSV is invalid: %v", err)
+		}
+		_, _ = w.Write([]byte(`{"id":777}`))
+	}))
+	defer server.Close()
+	s := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+
+	order := func(id string) string {
+		return `{"order_id":"` + id + `","order_date":"2026-10-18 10:30","pickup_location":"Home","billing_customer_name":"Naruto","billing_address":"Leaf Village","billing_city":"Mumbai","billing_pincode":"400001","billing_state":"Maharashtra","billing_country":"India","billing_email":"n@example.com","billing_phone":"+91 98765 43210","shipping_is_billing":true,"order_items":[{"name":"Ramen","sku":"RMN-1","units":2,"selling_price":"150"},{"name":"Kunai","sku":"KN-1","units":1,"selling_price":"99.5"}],"payment_method":"COD","sub_total":399.5,"length":10,"breadth":8,"height":4,"weight":0.5}`
+	}
+	jsonl := order("ORD-1") + "\n\n" + strings.Replace(order("ORD-2"), `"400001"`, `"4000"`, 1) + "\n" + order("ORD-3") + "\n" + order("ORD-1") + "\n{broken\n"
+	locations := map[string]int64{"home": 42}
+
+	importer := s.BulkImporter(BulkImportConfig{PickupLocationIDs: locations})
+	result, err := importer.ImportJSONL(context.Background(), strings.NewReader(jsonl))
+	if !errors.Is(err, ErrInvalidImportRows) || atomic.LoadInt32(&uploads) != 0 {
+		t.Fatalf("expected validation failure without upload, got %v", err)
+	}
+	if len(result.Invalid) != 3 {
+		t.Fatalf("unexpected invalid rows: %+v", result.Invalid)
+	}
+	byLine := map[int]ImportRowError{}
+	for _, invalid := range result.Invalid {
+		byLine[invalid.Line] = invalid
+	}
+	if byLine[3].Field != "billing_pincode" || byLine[3].OrderID != "ORD-2" || byLine[5].Field != "order_id" || byLine[6].Message == "" {
+		t.Fatalf("unexpected invalid rows: %+v", result.Invalid)
+	}
+
+	checker := &fakeImportChecker{status: &ImportStatus{Status: "2", Errors: []ImportErrorRow{
+		{Line: 2, Message: "Invalid SKU", Fields: map[string]string{"*Order Id": "ORD-3"}},
+		{Line: 3, SourceLine: 3, Message: "Courier not serviceable"},
+	}}}
+	importer = s.BulkImporter(BulkImportConfig{Checker: checker, SkipInvalid: true, PickupLocationIDs: locations})
+	result, err = importer.ImportJSONL(context.Background(), strings.NewReader(jsonl))
+	if err != nil {
+		t.Fatalf("ImportJSONL returned error: %v", err)
+	}
+	if result.ImportID != 777 || checker.importID != 777 || result.Submitted != 2 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+	if len(uploaded) != 5 || uploaded[0][0] != "*Order Id" || uploaded[1][0] != "ORD-1" || uploaded[1][1] != "18-10-2026 10:30" || uploaded[1][7] != "9876543210" || uploaded[2][21] != "KN-1" || uploaded[1][38] != "42" || uploaded[4][0] != "ORD-3" {
+		t.Fatalf("unexpected uploaded CSV: %q", uploaded)
+	}
+	if len(result.Rejected) != 2 || result.Rejected[0].Line != 4 || result.Rejected[1].Line != 1 || result.Rejected[1].OrderID != "ORD-1" {
+		t.Fatalf("unexpected rejected rows: %+v", result.Rejected)
+	}
+
+	csvInput := "order_id,order_date,pickup_location,billing_customer_name,billing_address,billing_city,billing_pincode,billing_state,billing_country,billing_phone,shipping_is_billing,payment_method,length,breadth,height,weight,name,sku,units,selling_price\n" +
+		"ORD-9,2026-10-18,Home,Sakura,Leaf Village,Pune,411001,Maharashtra,India,9876543210,true,Prepaid,10,8,4,0.5,Ramen,RMN-1,2,150\n" +
+		"ORD-9,,,,,,,,,,,,,,,,Kunai,KN-1,1,99\n" +
+		"ORD-10,2026-10-18,Home,Sasuke,Leaf Village,Pune,411001,Maharashtra,India,9876543210,true,Prepaid,10,8,4,0,Ramen,RMN-1,1,150\n"
+	defaultChecker := &fakeImportChecker{status: &ImportStatus{Status: "2"}}
+	s.SetImportChecker(defaultChecker)
+	importer = s.BulkImporter(BulkImportConfig{SkipInvalid: true, NoWait: true, PickupLocationIDs: locations})
+	if result, err = importer.ImportCSV(context.Background(), strings.NewReader(csvInput)); err != nil || result.Status != nil || defaultChecker.importID != 0 {
+		t.Fatalf("expected NoWait to skip the checker, got %+v err=%v", result, err)
+	}
+	importer = s.BulkImporter(BulkImportConfig{SkipInvalid: true, PickupLocationIDs: locations})
+	result, err = importer.ImportCSV(context.Background(), strings.NewReader(csvInput))
+	if err != nil {
+		t.Fatalf("ImportCSV returned error: %v", err)
+	}
+	if defaultChecker.importID != 777 || result.Status == nil {
+		t.Fatalf("expected the default checker to wait for the import, got %+v", result)
+	}
+	if result.Submitted != 1 || len(result.Invalid) != 1 || result.Invalid[0].Line != 4 || result.Invalid[0].Field != "weight" {
+		t.Fatalf("unexpected CSV result: %+v", result)
+	}
+	if len(uploaded) != 3 || uploaded[2][22] != "Kunai" || uploaded[1][3] != "Prepaid" {
+		t.Fatalf("unexpected uploaded CSV: %q", uploaded)
+	}
+
+	shipping := CreateCustomOrderRequest{OrderRequestFields: OrderRequestFields{
+		ReferenceOrderID:      "ORD-11",
+		OrderDate:             "2026-10-18",
+		PickupLocation:        "Home",
+		PaymentMethod:         "COD",
+		BillingCustomerName:   "Kakashi",
+		BillingAlternatePhone: "9123456780",
+		ShippingCustomerName:  "Kakashi",
+		ShippingAddress:       "Leaf Village",
+		ShippingCity:          "Pune",
+		ShippingState:         "Maharashtra",
+		ShippingCountry:       "India",
+		ShippingPincode:       "411001",
+		ShippingPhone:         "9876543210",
+		OrderItems:            []OrderItem{{Name: "Ramen", Sku: "RMN-1", Units: 1, SellingPrice: "150"}},
+		Length:                10,
+		Breadth:               8,
+		Height:                4,
+		Weight:                0.5,
+	}}
+	if _, err := importer.Import(context.Background(), []CreateCustomOrderRequest{shipping}); err != nil {
+		t.Fatalf("Import returned error: %v", err)
+	}
+	if len(uploaded) != 2 || uploaded[1][4] != "Kakashi" || uploaded[1][8] != "9123456780" || uploaded[1][38] != "42" {
+		t.Fatalf("unexpected uploaded CSV: %q", uploaded)
+	}
+
+	unmapped := shipping
+	unmapped.PickupLocation, unmapped.ChannelID = "Warehouse", "76893"
+	result, err = s.BulkImporter(BulkImportConfig{PickupLocationIDs: locations}).Import(context.Background(), []CreateCustomOrderRequest{unmapped, {}})
+	if !errors.Is(err, ErrInvalidImportRows) {
+		t.Fatalf("expected ErrInvalidImportRows, got %v", err)
+	}
+	fields := map[string]bool{}
+	for _, invalid := range result.Invalid {
+		fields[invalid.Field] = true
+	}
+	if !fields["pickup_location"] || !fields["channel_id"] {
+		t.Fatalf("unexpected invalid rows: %+v", result.Invalid)
+	}
+
+	if _, err := importer.Import(context.Background(), nil); !errors.Is(err, ErrNoImportRows) {
+		t.Fatalf("expected ErrNoImportRows, got %v", err)
+	}
+}
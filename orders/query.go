@@ -0,0 +1,227 @@
+package orders
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/internal/dates"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pagination"
+)
+
+// Query composes order filters. The list endpoint accepts a date range, a
+// channel, a pickup location and one filter_by/filter pair, so Query sends
+// what it can and applies every filter again to each order while paging:
+//
+//	it := client.Orders.Query(orders.NewQuery().
+//		Status("NEW").
+//		PaymentMethod(orders.PaymentMethodCOD).
+//		State("Maharashtra").
+//		CreatedBetween(yesterday, today))
+type Query struct {
+	statuses       []string
+	paymentMethods []string
+	createdFrom    time.Time
+	createdTo      time.Time
+	pincodes       []string
+	states         []string
+	channelIDs     []int64
+	skus           []string
+	pickupLocation string
+	search         string
+	perPage        int
+	sort           OrderSortDirection
+	sortBy         OrderSortBy
+	predicates     []func(OrderSummary) bool
+}
+
+func NewQuery() *Query {
+	return &Query{}
+}
+
+// Status keeps orders in any of the given statuses, such as "NEW".
+func (q *Query) Status(statuses ...string) *Query {
+	q.statuses = append(q.statuses, statuses...)
+	return q
+}
+
+func (q *Query) PaymentMethod(methods ...PaymentMethod) *Query {
+	for _, method := range methods {
+		q.paymentMethods = append(q.paymentMethods, string(method))
+	}
+	return q
+}
+
+// CreatedBetween keeps orders created at or after from and before to. A zero
+// time leaves that end open.
+func (q *Query) CreatedBetween(from, to time.Time) *Query {
+	q.createdFrom, q.createdTo = from, to
+	return q
+}
+
+// Pincode and State match the delivery address. Like PickupLocation, they
+// never match orders listed without the field.
+func (q *Query) Pincode(pincodes ...string) *Query {
+	q.pincodes = append(q.pincodes, pincodes...)
+	return q
+}
+
+func (q *Query) State(states ...string) *Query {
+	q.states = append(q.states, states...)
+	return q
+}
+
+func (q *Query) Channel(channelIDs ...int64) *Query {
+	q.channelIDs = append(q.channelIDs, channelIDs...)
+	return q
+}
+
+// SKU keeps orders containing at least one of the given channel SKUs.
+func (q *Query) SKU(skus ...string) *Query {
+	q.skus = append(q.skus, skus...)
+	return q
+}
+
+// PickupLocation matches the pickup location name, ignoring case. Orders
+// listed without one never match.
+func (q *Query) PickupLocation(name string) *Query {
+	q.pickupLocation = name
+	return q
+}
+
+// Search passes free text to the list endpoint's search parameter.
+func (q *Query) Search(text string) *Query {
+	q.search = text
+	return q
+}
+
+func (q *Query) Sort(by OrderSortBy, direction OrderSortDirection) *Query {
+	q.sortBy, q.sort = by, direction
+	return q
+}
+
+func (q *Query) PerPage(perPage int) *Query {
+	q.perPage = perPage
+	return q
+}
+
+// Where adds a custom client-side filter.
+func (q *Query) Where(keep func(OrderSummary) bool) *Query {
+	q.predicates = append(q.predicates, keep)
+	return q
+}
+
+// Params returns the list parameters sent to the API.
+func (q *Query) Params() OrdersListParams {
+	params := OrdersListParams{
+		PerPage:        q.perPage,
+		Sort:           q.sort,
+		SortBy:         q.sortBy,
+		Search:         q.search,
+		PickupLocation: q.pickupLocation,
+	}
+	// The API compares dates, so the sent range covers whole IST days and
+	// Match trims it to the exact times.
+	if !q.createdFrom.IsZero() {
+		params.From = q.createdFrom.In(dates.IST).Format("2006-01-02")
+	}
+	if !q.createdTo.IsZero() {
+		params.To = q.createdTo.Add(-time.Nanosecond).In(dates.IST).Format("2006-01-02")
+	}
+	if len(q.channelIDs) == 1 {
+		params.ChannelID = q.channelIDs[0]
+	}
+	switch {
+	case len(q.statuses) == 1:
+		params.FilterBy, params.Filter = OrderFilterByStatus, q.statuses[0]
+	case len(q.paymentMethods) == 1:
+		params.FilterBy, params.Filter = OrderFilterByPaymentMethod, q.paymentMethods[0]
+	}
+	return params
+}
+
+// Match reports whether order satisfies every filter in the query.
+func (q *Query) Match(order OrderSummary) bool {
+	if len(q.statuses) > 0 && !containsFold(q.statuses, order.Status) {
+		return false
+	}
+	if len(q.paymentMethods) > 0 && !containsFold(q.paymentMethods, string(order.PaymentMethod)) {
+		return false
+	}
+	if !q.createdFrom.IsZero() || !q.createdTo.IsZero() {
+		created, ok := dates.Parse(order.CreatedAt)
+		if !ok || (!q.createdFrom.IsZero() && created.Before(q.createdFrom)) || (!q.createdTo.IsZero() && !created.Before(q.createdTo)) {
+			return false
+		}
+	}
+	if len(q.pincodes) > 0 && !containsFold(q.pincodes, order.CustomerPincode.String()) {
+		return false
+	}
+	if len(q.states) > 0 && !containsFold(q.states, order.CustomerState) {
+		return false
+	}
+	if len(q.channelIDs) > 0 && !containsInt64(q.channelIDs, order.ChannelID) {
+		return false
+	}
+	if len(q.skus) > 0 && !orderHasSKU(order, q.skus) {
+		return false
+	}
+	if q.pickupLocation != "" && !strings.EqualFold(q.pickupLocation, order.PickupLocation) {
+		return false
+	}
+	for _, keep := range q.predicates {
+		if !keep(order) {
+			return false
+		}
+	}
+	return true
+}
+
+// Query returns an iterator over the orders matching q. Pages are fetched
+// lazily; filters the API cannot apply are checked on each order.
+func (s *Service) Query(q *Query, opts ...internalclient.CallOption) *pagination.Iterator[OrderSummary] {
+	if q == nil {
+		q = NewQuery()
+	}
+	base := q.Params()
+	pages := pagination.List(base.Page, base.PerPage, func(ctx context.Context, page int) ([]OrderSummary, pagination.Meta, error) {
+		params := base
+		params.Page = page
+		response, err := s.GetOrdersWithParams(ctx, &params, opts...)
+		if err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		meta := response.Meta.Pagination
+		return response.Data, pagination.Meta{Total: meta.Total, PerPage: meta.PerPage, TotalPages: meta.TotalPages}, nil
+	})
+	return pagination.Filter(pages, q.Match)
+}
+
+func orderHasSKU(order OrderSummary, skus []string) bool {
+	for _, product := range order.Products {
+		if containsFold(skus, product.ChannelSKU) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	for _, candidate := range values {
+		if strings.EqualFold(strings.TrimSpace(candidate), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsInt64(values []int64, value int64) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
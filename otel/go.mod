@@ -0,0 +1,19 @@
+module github.com/Niyantra-Labs/shiprocket-gosdk/otel
+
+go 1.22
+
+require (
+	github.com/Niyantra-Labs/shiprocket-gosdk v0.0.0-20261019071727-5e72c3d32e39
+	go.opentelemetry.io/otel v1.31.0
+	go.opentelemetry.io/otel/metric v1.31.0
+	go.opentelemetry.io/otel/sdk v1.31.0
+	go.opentelemetry.io/otel/sdk/metric v1.31.0
+	go.opentelemetry.io/otel/trace v1.31.0
+)
+
+require (
+	github.com/go-logr/logr v1.4.2 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/google/uuid v1.6.0 // indirect
+	golang.org/x/sys v0.26.0 // indirect
+)
@@ -0,0 +1,169 @@
+// Package otel instruments shiprocket clients with OpenTelemetry. It records
+// one client span per SDK operation, a request duration histogram and an
+// error counter. Attributes use the path template instead of the raw URL so
+// AWBs and order IDs do not end up in metric labels.
+//
+//	observer, err := otel.New(otel.Config{})
+//	client := shiprocket.NewClient(shiprocket.Config{
+//		Token:     token,
+//		Observers: []shiprocket.Observer{observer},
+//	})
+package otel
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	shiprocket "github.com/Niyantra-Labs/shiprocket-gosdk"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Niyantra-Labs/shiprocket-gosdk/otel"
+
+const (
+	AttributeOperation = attribute.Key("shiprocket.operation")
+	AttributeRetries   = attribute.Key("shiprocket.retries")
+)
+
+type Config struct {
+	// TracerProvider and MeterProvider default to the global providers.
+	TracerProvider trace.TracerProvider
+	MeterProvider  metric.MeterProvider
+}
+
+// Observer implements shiprocket.Observer.
+type Observer struct {
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+	now      func() time.Time
+}
+
+var _ shiprocket.Observer = (*Observer)(nil)
+
+func New(cfg Config) (*Observer, error) {
+	if cfg.TracerProvider == nil {
+		cfg.TracerProvider = otel.GetTracerProvider()
+	}
+	if cfg.MeterProvider == nil {
+		cfg.MeterProvider = otel.GetMeterProvider()
+	}
+
+	meter := cfg.MeterProvider.Meter(instrumentationName)
+	duration, err := meter.Float64Histogram(
+		"shiprocket.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of Shiprocket SDK operations, including retries."),
+	)
+	if err != nil {
+		return nil, err
+	}
+	errorCount, err := meter.Int64Counter(
+		"shiprocket.client.errors",
+		metric.WithUnit("{error}"),
+		metric.WithDescription("Shiprocket SDK operations that returned an error."),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Observer{
+		tracer:   cfg.TracerProvider.Tracer(instrumentationName),
+		duration: duration,
+		errors:   errorCount,
+		now:      time.Now,
+	}, nil
+}
+
+func (o *Observer) StartOperation(ctx context.Context, op shiprocket.Operation) (context.Context, func(shiprocket.OperationResult)) {
+	name := op.Name
+	if name == "" {
+		name = op.Method + " " + op.PathTemplate
+	}
+	attrs := []attribute.KeyValue{
+		AttributeOperation.String(name),
+		attribute.String("http.request.method", op.Method),
+		attribute.String("url.template", op.PathTemplate),
+	}
+
+	started := o.now()
+	ctx, span := o.tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+
+	return ctx, func(result shiprocket.OperationResult) {
+		var resultAttrs []attribute.KeyValue
+		if result.StatusCode > 0 {
+			resultAttrs = append(resultAttrs, attribute.Int("http.response.status_code", result.StatusCode))
+		}
+		class := ErrorClass(result.Err, result.StatusCode)
+		if class != "" {
+			resultAttrs = append(resultAttrs, attribute.String("error.type", class))
+		}
+
+		span.SetAttributes(resultAttrs...)
+		span.SetAttributes(AttributeRetries.Int(max(result.Attempts-1, 0)))
+		if class != "" {
+			description := class
+			if result.Err != nil {
+				description = result.Err.Error()
+				span.RecordError(result.Err)
+			}
+			span.SetStatus(codes.Error, description)
+		}
+		span.End()
+
+		set := metric.WithAttributes(append(attrs, resultAttrs...)...)
+		o.duration.Record(ctx, o.now().Sub(started).Seconds(), set)
+		if class != "" {
+			o.errors.Add(ctx, 1, set)
+		}
+	}
+}
+
+// ErrorClass names the SDK error type of err, such as "RateLimitError" or
+// "TransportError". Responses returned without an error but with a status of
+// 400 or above are classed by their status code. It returns "" on success.
+func ErrorClass(err error, statusCode int) string {
+	var (
+		authErr       *shiprocket.AuthError
+		rateLimitErr  *shiprocket.RateLimitError
+		validationErr *shiprocket.ValidationError
+		businessErr   *shiprocket.BusinessError
+		serverErr     *shiprocket.ServerError
+		apiErr        *shiprocket.APIError
+		transportErr  *shiprocket.TransportError
+		circuitErr    *shiprocket.CircuitOpenError
+	)
+	switch {
+	case err == nil && statusCode >= 400:
+		return strconv.Itoa(statusCode)
+	case err == nil:
+		return ""
+	case errors.As(err, &authErr):
+		return "AuthError"
+	case errors.As(err, &rateLimitErr):
+		return "RateLimitError"
+	case errors.As(err, &validationErr):
+		return "ValidationError"
+	case errors.As(err, &businessErr):
+		return "BusinessError"
+	case errors.As(err, &serverErr):
+		return "ServerError"
+	case errors.As(err, &apiErr):
+		return "APIError"
+	case errors.As(err, &transportErr):
+		return "TransportError"
+	case errors.As(err, &circuitErr):
+		return "CircuitOpenError"
+	default:
+		return "_OTHER"
+	}
+}
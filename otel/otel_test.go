@@ -0,0 +1,126 @@
+package otel
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	shiprocket "github.com/Niyantra-Labs/shiprocket-gosdk"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObserverRecordsSpansAndMetrics(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/external/courier/track/awb/429" {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"tracking_data":{"track_status":1}}`))
+	}))
+	defer server.Close()
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	observer, err := New(Config{
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		MeterProvider:  sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	})
+	if err != nil {
+		t.Fatalf("New returned error: %v", err)
+	}
+
+	client := shiprocket.NewClient(shiprocket.Config{
+		BaseURL:   server.URL,
+		Token:     "token",
+		Observers: []shiprocket.Observer{observer},
+	})
+	for _, awb := range []string{"141123221084922", "429"} {
+		_, _ = client.Shipments.TrackByAWB(context.Background(), &shipment.TrackByAWBRequest{AWBCode: awb})
+	}
+
+	ended := spans.Ended()
+	if len(ended) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(ended))
+	}
+	for _, span := range ended {
+		if span.Name() != "shipment.TrackByAWB" {
+			t.Fatalf("unexpected span name: %s", span.Name())
+		}
+		attrs := attribute.NewSet(span.Attributes()...)
+		if value, _ := attrs.Value("url.template"); value.AsString() != "/v1/external/courier/track/awb/{awb_code}" {
+			t.Fatalf("unexpected path template: %q", value.AsString())
+		}
+		if value, ok := attrs.Value(AttributeRetries); !ok || value.AsInt64() != 0 {
+			t.Fatalf("unexpected retries attribute: %v", value)
+		}
+	}
+	failed := attribute.NewSet(ended[1].Attributes()...)
+	if value, _ := failed.Value("error.type"); value.AsString() != "RateLimitError" {
+		t.Fatalf("unexpected error type: %q", value.AsString())
+	}
+	if value, _ := failed.Value("http.response.status_code"); value.AsInt64() != http.StatusTooManyRequests {
+		t.Fatalf("unexpected status code: %d", value.AsInt64())
+	}
+	if ended[1].Status().Code != codes.Error || ended[0].Status().Code == codes.Error {
+		t.Fatalf("unexpected span statuses: %v %v", ended[0].Status(), ended[1].Status())
+	}
+
+	var metrics metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &metrics); err != nil {
+		t.Fatalf("Collect returned error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, scope := range metrics.ScopeMetrics {
+		for _, m := range scope.Metrics {
+			found[m.Name] = true
+			switch data := m.Data.(type) {
+			case metricdata.Histogram[float64]:
+				if len(data.DataPoints) != 2 {
+					t.Fatalf("expected one duration series per outcome, got %d", len(data.DataPoints))
+				}
+			case metricdata.Sum[int64]:
+				if len(data.DataPoints) != 1 || data.DataPoints[0].Value != 1 {
+					t.Fatalf("unexpected error counter: %+v", data.DataPoints)
+				}
+				if value, _ := data.DataPoints[0].Attributes.Value("error.type"); value.AsString() != "RateLimitError" {
+					t.Fatalf("unexpected error counter attributes: %v", data.DataPoints[0].Attributes)
+				}
+			}
+		}
+	}
+	if !found["shiprocket.client.request.duration"] || !found["shiprocket.client.errors"] {
+		t.Fatalf("missing metrics: %v", found)
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		statusCode int
+		want       string
+	}{
+		{name: "success", want: ""},
+		{name: "raw error status", statusCode: http.StatusBadGateway, want: "502"},
+		{name: "server", err: &shiprocket.ServerError{APIError: &shiprocket.APIError{}}, statusCode: 500, want: "ServerError"},
+		{name: "transport", err: &shiprocket.TransportError{Err: context.DeadlineExceeded}, want: "TransportError"},
+		{name: "circuit open", err: &shiprocket.CircuitOpenError{Group: "tracking"}, want: "CircuitOpenError"},
+		{name: "other", err: context.Canceled, want: "_OTHER"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ErrorClass(tt.err, tt.statusCode); got != tt.want {
+				t.Fatalf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package pagination walks paginated list endpoints one item at a time.
+package pagination
+
+import "context"
+
+// PageInfo describes the page a FetchFunc returned. TotalPages may be zero
+// when the endpoint does not report it; iteration then stops at the first
+// empty or short page.
+type PageInfo struct {
+	CurrentPage int
+	TotalPages  int
+	PerPage     int
+	Total       int
+}
+
+// FetchFunc loads one page, numbered from 1.
+type FetchFunc[T any] func(ctx context.Context, page int) ([]T, PageInfo, error)
+
+// Iterator fetches pages lazily as Next is called:
+//
+//	it := client.Products.ListIterator(nil)
+//	for it.Next(ctx) {
+//		product := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type Iterator[T any] struct {
+	fetch FetchFunc[T]
+	page  int
+	info  PageInfo
+	items []T
+	index int
+	value T
+	done  bool
+	err   error
+}
+
+func New[T any](fetch FetchFunc[T]) *Iterator[T] {
+	return &Iterator[T]{fetch: fetch}
+}
+
+// Next advances to the next item, fetching the next page when needed. It
+// returns false when the items are exhausted or a fetch fails.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	for it.index >= len(it.items) {
+		if it.done || it.err != nil {
+			return false
+		}
+		if err := ctx.Err(); err != nil {
+			it.err = err
+			return false
+		}
+		it.page++
+		items, info, err := it.fetch(ctx, it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.items, it.index, it.info = items, 0, info
+		if info.CurrentPage > 0 {
+			it.page = info.CurrentPage
+		}
+		it.done = it.lastPage(len(items))
+	}
+	it.value = it.items[it.index]
+	it.index++
+	return true
+}
+
+func (it *Iterator[T]) lastPage(count int) bool {
+	switch {
+	case count == 0:
+		return true
+	case it.info.TotalPages > 0:
+		return it.page >= it.info.TotalPages
+	case it.info.PerPage > 0:
+		return count < it.info.PerPage
+	default:
+		return false
+	}
+}
+
+// Value returns the current item.
+func (it *Iterator[T]) Value() T {
+	return it.value
+}
+
+// Err returns the error that stopped iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// PageInfo returns the metadata of the most recently fetched page.
+func (it *Iterator[T]) PageInfo() PageInfo {
+	return it.info
+}
+
+// Meta is the pagination block of a Shiprocket list response.
+type Meta struct {
+	Total      int
+	PerPage    int
+	TotalPages int
+}
+
+// ListFunc loads one page of a Shiprocket list endpoint.
+type ListFunc[T any] func(ctx context.Context, page int) ([]T, Meta, error)
+
+// List returns an iterator over a page-numbered list endpoint. Iteration
+// starts at start when it is above 1. perPage is the requested page size,
+// reported in PageInfo when the response does not echo it.
+func List[T any](start, perPage int, list ListFunc[T]) *Iterator[T] {
+	return New(func(ctx context.Context, page int) ([]T, PageInfo, error) {
+		if page == 1 && start > 1 {
+			page = start
+		}
+		items, meta, err := list(ctx, page)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		size := perPage
+		if meta.PerPage > 0 {
+			size = meta.PerPage
+		}
+		return items, PageInfo{CurrentPage: page, TotalPages: meta.TotalPages, PerPage: size, Total: meta.Total}, nil
+	})
+}
+
+// Collect drains the iterator into a slice.
+func Collect[T any](ctx context.Context, it *Iterator[T]) ([]T, error) {
+	var items []T
+	for it.Next(ctx) {
+		items = append(items, it.Value())
+	}
+	return items, it.Err()
+}
+
+// Filter returns an iterator over the items of it for which keep is true.
+func Filter[T any](it *Iterator[T], keep func(T) bool) *Iterator[T] {
+	return New(func(ctx context.Context, _ int) ([]T, PageInfo, error) {
+		for it.Next(ctx) {
+			if value := it.Value(); keep(value) {
+				return []T{value}, PageInfo{}, nil
+			}
+		}
+		return nil, PageInfo{}, it.Err()
+	})
+}
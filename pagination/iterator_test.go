@@ -0,0 +1,107 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestIterator(t *testing.T) {
+	pages := [][]int{{1, 2}, {3, 4}, {5}}
+
+	tests := []struct {
+		name      string
+		info      func(page int) PageInfo
+		wantItems []int
+		wantCalls int
+	}{
+		{
+			name:      "stops at total pages",
+			info:      func(page int) PageInfo { return PageInfo{CurrentPage: page, TotalPages: 2} },
+			wantItems: []int{1, 2, 3, 4},
+			wantCalls: 2,
+		},
+		{
+			name:      "stops at a short page",
+			info:      func(page int) PageInfo { return PageInfo{PerPage: 2} },
+			wantItems: []int{1, 2, 3, 4, 5},
+			wantCalls: 3,
+		},
+		{
+			name:      "stops at an empty page",
+			info:      func(page int) PageInfo { return PageInfo{} },
+			wantItems: []int{1, 2, 3, 4, 5},
+			wantCalls: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			it := New(func(_ context.Context, page int) ([]int, PageInfo, error) {
+				calls++
+				if page > len(pages) {
+					return nil, tt.info(page), nil
+				}
+				return pages[page-1], tt.info(page), nil
+			})
+			items, err := Collect(context.Background(), it)
+			if err != nil {
+				t.Fatalf("Collect returned error: %v", err)
+			}
+			if len(items) != len(tt.wantItems) || calls != tt.wantCalls {
+				t.Fatalf("expected %v in %d calls, got %v in %d", tt.wantItems, tt.wantCalls, items, calls)
+			}
+			for i := range items {
+				if items[i] != tt.wantItems[i] {
+					t.Fatalf("expected %v, got %v", tt.wantItems, items)
+				}
+			}
+		})
+	}
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	boom := errors.New("boom")
+	it := New(func(_ context.Context, page int) ([]string, PageInfo, error) {
+		if page == 2 {
+			return nil, PageInfo{}, boom
+		}
+		return []string{"a"}, PageInfo{}, nil
+	})
+	items, err := Collect(context.Background(), it)
+	if !errors.Is(err, boom) || len(items) != 1 {
+		t.Fatalf("expected one item and boom, got %v %v", items, err)
+	}
+	if it.Next(context.Background()) {
+		t.Fatal("expected Next to stay false after an error")
+	}
+}
+
+func TestFilter(t *testing.T) {
+	it := New(func(_ context.Context, page int) ([]int, PageInfo, error) {
+		return []int{1, 2, 3, 4, 5, 6}, PageInfo{TotalPages: 1}, nil
+	})
+	even, err := Collect(context.Background(), Filter(it, func(v int) bool { return v%2 == 0 }))
+	if err != nil || len(even) != 3 || even[2] != 6 {
+		t.Fatalf("unexpected filtered items %v err=%v", even, err)
+	}
+}
+
+func TestListStartsAtPageAndKeepsRequestedSize(t *testing.T) {
+	var requested []int
+	it := List(3, 2, func(_ context.Context, page int) ([]int, Meta, error) {
+		requested = append(requested, page)
+		if page == 3 {
+			return []int{5, 6}, Meta{}, nil
+		}
+		return []int{7}, Meta{}, nil
+	})
+	items, err := Collect(context.Background(), it)
+	if err != nil || len(items) != 3 || len(requested) != 2 || requested[0] != 3 || requested[1] != 4 {
+		t.Fatalf("unexpected items %v from pages %v err=%v", items, requested, err)
+	}
+	if info := it.PageInfo(); info.CurrentPage != 4 || info.PerPage != 2 {
+		t.Fatalf("unexpected page info %+v", info)
+	}
+}
@@ -15,25 +15,27 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/settings/company/pickup",
-	}, &response); err != nil {
+		Operation: "pickupaddress.List",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/settings/company/pickup",
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) Create(ctx context.Context, request *CreateRequest) (*CreateResponse, error) {
+func (s *Service) Create(ctx context.Context, request *CreateRequest, opts ...internalclient.CallOption) (*CreateResponse, error) {
 	var response CreateResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/settings/company/addpickup",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "pickupaddress.Create",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/settings/company/addpickup",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
@@ -39,6 +39,9 @@ func TestPickupAddressEndpointsSendDocumentedRequests(t *testing.T) {
 				if !response.Data.AllowMore.Bool() || !response.Data.ShippingAddresses[0].IsPrimaryLocation.Bool() {
 					t.Fatalf("unexpected pickup address data: %+v", response.Data.ShippingAddresses[0])
 				}
+				if ids := response.LocationIDs(); ids["Primary"] != 1856901 {
+					t.Fatalf("unexpected location IDs: %v", ids)
+				}
 				return nil
 			},
 		},
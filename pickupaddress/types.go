@@ -32,6 +32,16 @@ type ListResponse struct {
 	Data PickupAddressesData `json:"data"`
 }
 
+// LocationIDs maps each pickup location name to its ID, for
+// orders.BulkImportConfig.PickupLocationIDs.
+func (r *ListResponse) LocationIDs() map[string]int64 {
+	ids := make(map[string]int64, len(r.Data.ShippingAddresses))
+	for _, address := range r.Data.ShippingAddresses {
+		ids[address.PickupLocation] = address.ID
+	}
+	return ids
+}
+
 type PickupAddressesData struct {
 	ShippingAddresses []PickupAddress   `json:"shipping_address"`
 	AllowMore         FlexibleBool      `json:"allow_more"`
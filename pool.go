@@ -0,0 +1,317 @@
+package shiprocket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/auth"
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+)
+
+const defaultPoolIdleTimeout = 30 * time.Minute
+
+var ErrTenantRequired = errors.New("shiprocket tenant ID is required")
+
+// TenantCredentials authenticates one tenant. A static Token takes precedence
+// over Email and Password, which are exchanged for a token on first use.
+type TenantCredentials struct {
+	Token    string
+	Email    string
+	Password string
+	BaseURL  string
+}
+
+type CredentialsProvider interface {
+	Credentials(ctx context.Context, tenantID string) (TenantCredentials, error)
+}
+
+type CredentialsProviderFunc func(ctx context.Context, tenantID string) (TenantCredentials, error)
+
+func (f CredentialsProviderFunc) Credentials(ctx context.Context, tenantID string) (TenantCredentials, error) {
+	return f(ctx, tenantID)
+}
+
+type PoolConfig struct {
+	// Config holds the settings shared by every tenant client. Its token and
+	// credential fields are ignored in favour of the CredentialsProvider.
+	Config
+	CredentialsProvider CredentialsProvider
+	// Transport is shared by all tenant clients. Defaults to the transport of
+	// Config.HTTPClient, or a clone of http.DefaultTransport.
+	Transport http.RoundTripper
+	// IdleTimeout evicts clients that have not been requested for this long.
+	// Defaults to 30 minutes; a negative value disables eviction.
+	IdleTimeout time.Duration
+	// RateLimit caps requests per second for each tenant. Zero disables it.
+	RateLimit float64
+	// Burst is the number of requests a tenant may send at once when
+	// RateLimit is set. Defaults to 1.
+	Burst int
+	// TenantHooks and TenantMiddleware add per-tenant observability, for
+	// example metrics hooks labelled with the tenant ID.
+	TenantHooks      func(tenantID string) []Hook
+	TenantMiddleware func(tenantID string) []Middleware
+}
+
+// Pool lazily builds one Client per tenant. Tenants share a transport and
+// connection pool but keep separate token caches and rate limits.
+type Pool struct {
+	config    PoolConfig
+	transport http.RoundTripper
+	now       func() time.Time
+
+	mu      sync.Mutex
+	tenants map[string]*poolEntry
+}
+
+type poolEntry struct {
+	ready    chan struct{}
+	client   *Client
+	err      error
+	lastUsed time.Time
+}
+
+func NewPool(cfg PoolConfig) *Pool {
+	transport := cfg.Transport
+	if transport == nil && cfg.HTTPClient != nil {
+		transport = cfg.HTTPClient.Transport
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+		if defaultTransport, ok := transport.(*http.Transport); ok {
+			transport = defaultTransport.Clone()
+		}
+	}
+	if cfg.IdleTimeout == 0 {
+		cfg.IdleTimeout = defaultPoolIdleTimeout
+	}
+
+	return &Pool{
+		config:    cfg,
+		transport: transport,
+		now:       time.Now,
+		tenants:   map[string]*poolEntry{},
+	}
+}
+
+// Client returns the client for tenantID, building it on first use. Idle
+// clients of other tenants are evicted as a side effect.
+func (p *Pool) Client(ctx context.Context, tenantID string) (*Client, error) {
+	if tenantID == "" {
+		return nil, ErrTenantRequired
+	}
+
+	p.mu.Lock()
+	now := p.now()
+	p.evictLocked(now)
+	entry, ok := p.tenants[tenantID]
+	if ok {
+		entry.lastUsed = now
+		p.mu.Unlock()
+
+		select {
+		case <-entry.ready:
+			return entry.client, entry.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	entry = &poolEntry{ready: make(chan struct{}), lastUsed: now}
+	p.tenants[tenantID] = entry
+	p.mu.Unlock()
+
+	entry.client, entry.err = p.build(ctx, tenantID)
+	if entry.err != nil {
+		p.mu.Lock()
+		if p.tenants[tenantID] == entry {
+			delete(p.tenants, tenantID)
+		}
+		p.mu.Unlock()
+	}
+	close(entry.ready)
+
+	return entry.client, entry.err
+}
+
+// Remove drops the cached client for tenantID, for example after its
+// credentials were rotated.
+func (p *Pool) Remove(tenantID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tenants, tenantID)
+}
+
+// EvictIdle drops clients that have been idle longer than IdleTimeout and
+// returns how many were removed.
+func (p *Pool) EvictIdle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evictLocked(p.now())
+}
+
+func (p *Pool) Tenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenants := make([]string, 0, len(p.tenants))
+	for tenantID := range p.tenants {
+		tenants = append(tenants, tenantID)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+func (p *Pool) evictLocked(now time.Time) int {
+	if p.config.IdleTimeout < 0 {
+		return 0
+	}
+	evicted := 0
+	for tenantID, entry := range p.tenants {
+		if now.Sub(entry.lastUsed) > p.config.IdleTimeout {
+			delete(p.tenants, tenantID)
+			evicted++
+		}
+	}
+	return evicted
+}
+
+func (p *Pool) build(ctx context.Context, tenantID string) (*Client, error) {
+	if p.config.CredentialsProvider == nil {
+		return nil, errors.New("shiprocket pool has no credentials provider")
+	}
+	credentials, err := p.config.CredentialsProvider.Credentials(ctx, tenantID)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := p.config.Config
+	cfg.Token = credentials.Token
+	cfg.TokenSource = nil
+	cfg.Credentials = nil
+	if credentials.Token == "" {
+		if credentials.Email == "" || credentials.Password == "" {
+			return nil, auth.ErrCredentialsRequired
+		}
+		cfg.Credentials = &Credentials{Email: credentials.Email, Password: credentials.Password}
+	}
+	if credentials.BaseURL != "" {
+		cfg.BaseURL = credentials.BaseURL
+	}
+
+	cfg.HTTPClient = &http.Client{}
+	if p.config.HTTPClient != nil {
+		*cfg.HTTPClient = *p.config.HTTPClient
+	}
+	cfg.HTTPClient.Transport = p.transport
+	if p.config.Timeout != 0 {
+		cfg.HTTPClient.Timeout = p.config.Timeout
+	}
+	if cfg.Logger != nil {
+		cfg.Logger = tenantLogger{logger: cfg.Logger, tenantID: tenantID}
+	}
+	if cfg.Slog != nil {
+		cfg.Slog = cfg.Slog.With("tenant", tenantID)
+	}
+
+	var middleware []Middleware
+	if p.config.RateLimit > 0 {
+		middleware = append(middleware, rateLimitMiddleware(newRateLimiter(p.config.RateLimit, p.config.Burst)))
+	}
+	middleware = append(middleware, p.config.Middleware...)
+	if p.config.TenantMiddleware != nil {
+		middleware = append(middleware, p.config.TenantMiddleware(tenantID)...)
+	}
+	cfg.Middleware = middleware
+
+	cfg.Hooks = append([]Hook(nil), p.config.Hooks...)
+	if p.config.TenantHooks != nil {
+		cfg.Hooks = append(cfg.Hooks, p.config.TenantHooks(tenantID)...)
+	}
+
+	client := NewClient(cfg)
+	client.core.Tenant = tenantID
+	return client, nil
+}
+
+// WithTenant attaches a tenant ID to ctx. Pool clients add it to the context
+// of every call, before observers and hooks run, so they and middleware can
+// label logs and metrics.
+func WithTenant(ctx context.Context, tenantID string) context.Context {
+	return internalclient.WithTenant(ctx, tenantID)
+}
+
+func TenantFromContext(ctx context.Context) (string, bool) {
+	return internalclient.TenantFromContext(ctx)
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+type tenantLogger struct {
+	logger   Logger
+	tenantID string
+}
+
+func (l tenantLogger) Printf(format string, args ...any) {
+	l.logger.Printf("tenant=%s "+format, append([]any{l.tenantID}, args...)...)
+}
+
+// rateLimiter is a token bucket. Requests wait for a token instead of failing.
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens = min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func rateLimitMiddleware(limiter *rateLimiter) Middleware {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			if err := limiter.wait(req.Context()); err != nil {
+				closeRequestBody(req)
+				return nil, err
+			}
+			return next.RoundTrip(req)
+		})
+	}
+}
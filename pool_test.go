@@ -0,0 +1,295 @@
+package shiprocket
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type recordingLogger struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (l *recordingLogger) Printf(format string, args ...any) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.lines = append(l.lines, fmt.Sprintf(format, args...))
+}
+
+func TestPoolIsolatesTenantsAndSharesTransport(t *testing.T) {
+	var logins sync.Map
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/external/auth/login":
+			var body struct {
+				Email string `json:"email"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			count, _ := logins.LoadOrStore(body.Email, new(int32))
+			atomic.AddInt32(count.(*int32), 1)
+			_, _ = fmt.Fprintf(w, `{"token":"token-%s"}`, strings.Split(body.Email, "@")[0])
+		case "/v1/external/account/details/wallet-balance":
+			_, _ = fmt.Fprintf(w, `{"data":{"balance_amount":"%s"}}`, strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	var providerCalls int32
+	var transportCalls int32
+	var tagged sync.Map
+	logger := &recordingLogger{}
+	pool := NewPool(PoolConfig{
+		Config: Config{BaseURL: server.URL, Logger: logger},
+		CredentialsProvider: CredentialsProviderFunc(func(ctx context.Context, tenantID string) (TenantCredentials, error) {
+			atomic.AddInt32(&providerCalls, 1)
+			if tenantID == "unknown" {
+				return TenantCredentials{}, errors.New("tenant not configured")
+			}
+			return TenantCredentials{Email: tenantID + "@example.com", Password: "secret"}, nil
+		}),
+		Transport: roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&transportCalls, 1)
+			return http.DefaultTransport.RoundTrip(req)
+		}),
+		TenantMiddleware: func(tenantID string) []Middleware {
+			return []Middleware{func(next http.RoundTripper) http.RoundTripper {
+				return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+					if fromContext, _ := TenantFromContext(req.Context()); fromContext != tenantID {
+						t.Errorf("request context tenant %q does not match %q", fromContext, tenantID)
+					}
+					tagged.Store(tenantID, true)
+					return next.RoundTrip(req)
+				})
+			}}
+		},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for _, tenantID := range []string{"acme", "globex"} {
+			wg.Add(1)
+			go func(tenantID string) {
+				defer wg.Done()
+				client, err := pool.Client(context.Background(), tenantID)
+				if err != nil {
+					t.Errorf("Client returned error: %v", err)
+					return
+				}
+				balance, err := client.Account.GetWalletBalance(context.Background())
+				if err != nil {
+					t.Errorf("GetWalletBalance returned error: %v", err)
+					return
+				}
+				if balance.Data.BalanceAmount.String() != "token-"+tenantID {
+					t.Errorf("tenant %s used token %s", tenantID, balance.Data.BalanceAmount)
+				}
+			}(tenantID)
+		}
+	}
+	wg.Wait()
+
+	if providerCalls != 2 {
+		t.Fatalf("expected one provider call per tenant, got %d", providerCalls)
+	}
+	for _, tenantID := range []string{"acme", "globex"} {
+		count, _ := logins.Load(tenantID + "@example.com")
+		if count == nil || atomic.LoadInt32(count.(*int32)) != 1 {
+			t.Fatalf("expected one login for %s", tenantID)
+		}
+		if _, ok := tagged.Load(tenantID); !ok {
+			t.Fatalf("expected tenant middleware for %s", tenantID)
+		}
+	}
+	if transportCalls != 22 {
+		t.Fatalf("expected all requests on the shared transport, got %d", transportCalls)
+	}
+	for _, line := range logger.lines {
+		if !strings.HasPrefix(line, "tenant=acme ") && !strings.HasPrefix(line, "tenant=globex ") {
+			t.Fatalf("log line without tenant: %q", line)
+		}
+	}
+
+	if _, err := pool.Client(context.Background(), "unknown"); err == nil {
+		t.Fatal("expected provider error")
+	}
+	if _, err := pool.Client(context.Background(), ""); !errors.Is(err, ErrTenantRequired) {
+		t.Fatalf("expected ErrTenantRequired, got %v", err)
+	}
+	if got := strings.Join(pool.Tenants(), ","); got != "acme,globex" {
+		t.Fatalf("unexpected tenants: %s", got)
+	}
+}
+
+type observerFunc func(ctx context.Context, op Operation) (context.Context, func(OperationResult))
+
+func (f observerFunc) StartOperation(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+	return f(ctx, op)
+}
+
+func TestPoolTenantReachesHooksAndObservers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"balance_amount":"1"}}`))
+	}))
+	defer server.Close()
+
+	var mu sync.Mutex
+	var seen []string
+	record := func(source string, ctx context.Context) {
+		tenantID, _ := TenantFromContext(ctx)
+		mu.Lock()
+		seen = append(seen, source+"="+tenantID)
+		mu.Unlock()
+	}
+	jar := &recordingJar{}
+	checkRedirect := func(*http.Request, []*http.Request) error { return nil }
+	pool := NewPool(PoolConfig{
+		Config: Config{
+			BaseURL:    server.URL,
+			HTTPClient: &http.Client{Jar: jar, CheckRedirect: checkRedirect},
+			Observers: []Observer{observerFunc(func(ctx context.Context, op Operation) (context.Context, func(OperationResult)) {
+				record("observer", ctx)
+				record("operation", WithTenant(ctx, op.Tenant))
+				return ctx, nil
+			})},
+			Hooks: []Hook{operationHook{
+				before: func(req *http.Request) { record("before", req.Context()) },
+				after:  func(*http.Response, error) {},
+			}},
+		},
+		CredentialsProvider: CredentialsProviderFunc(func(ctx context.Context, tenantID string) (TenantCredentials, error) {
+			return TenantCredentials{Token: "token"}, nil
+		}),
+	})
+
+	client, err := pool.Client(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if client.Config.HTTPClient.Jar != jar || client.Config.HTTPClient.CheckRedirect == nil {
+		t.Fatal("expected the pool to keep the cookie jar and redirect policy")
+	}
+	if _, err := client.Account.GetWalletBalance(context.Background()); err != nil {
+		t.Fatalf("GetWalletBalance returned error: %v", err)
+	}
+	if got := strings.Join(seen, ","); got != "observer=acme,operation=acme,before=acme" {
+		t.Fatalf("unexpected tenant visibility: %s", got)
+	}
+	if jar.calls == 0 {
+		t.Fatal("expected requests to use the cookie jar")
+	}
+}
+
+type recordingJar struct {
+	mu    sync.Mutex
+	calls int
+}
+
+func (j *recordingJar) SetCookies(*url.URL, []*http.Cookie) {}
+
+func (j *recordingJar) Cookies(*url.URL) []*http.Cookie {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	j.calls++
+	return nil
+}
+
+func TestPoolEvictsIdleClients(t *testing.T) {
+	var providerCalls int32
+	pool := NewPool(PoolConfig{
+		IdleTimeout: time.Minute,
+		CredentialsProvider: CredentialsProviderFunc(func(ctx context.Context, tenantID string) (TenantCredentials, error) {
+			atomic.AddInt32(&providerCalls, 1)
+			return TenantCredentials{Token: "token-" + tenantID}, nil
+		}),
+	})
+	now := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+	pool.now = func() time.Time { return now }
+
+	first, err := pool.Client(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+	if _, err := pool.Client(context.Background(), "globex"); err != nil {
+		t.Fatalf("Client returned error: %v", err)
+	}
+
+	now = now.Add(45 * time.Second)
+	again, _ := pool.Client(context.Background(), "acme")
+	if again != first {
+		t.Fatal("expected cached client before the idle timeout")
+	}
+
+	now = now.Add(30 * time.Second)
+	if evicted := pool.EvictIdle(); evicted != 1 {
+		t.Fatalf("expected globex to be evicted, got %d", evicted)
+	}
+	if got := strings.Join(pool.Tenants(), ","); got != "acme" {
+		t.Fatalf("unexpected tenants: %s", got)
+	}
+
+	now = now.Add(2 * time.Minute)
+	rebuilt, _ := pool.Client(context.Background(), "acme")
+	if rebuilt == first || providerCalls != 3 {
+		t.Fatalf("expected acme to be rebuilt after eviction, provider calls %d", providerCalls)
+	}
+
+	pool.Remove("acme")
+	if len(pool.Tenants()) != 0 {
+		t.Fatalf("expected empty pool, got %v", pool.Tenants())
+	}
+}
+
+func TestPoolRateLimitsEachTenant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"balance_amount":"1"}}`))
+	}))
+	defer server.Close()
+
+	pool := NewPool(PoolConfig{
+		Config:    Config{BaseURL: server.URL},
+		RateLimit: 20,
+		Burst:     1,
+		CredentialsProvider: CredentialsProviderFunc(func(ctx context.Context, tenantID string) (TenantCredentials, error) {
+			return TenantCredentials{Token: "token"}, nil
+		}),
+	})
+
+	acme, _ := pool.Client(context.Background(), "acme")
+	globex, _ := pool.Client(context.Background(), "globex")
+
+	started := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := acme.Account.GetWalletBalance(context.Background()); err != nil {
+			t.Fatalf("GetWalletBalance returned error: %v", err)
+		}
+	}
+	if elapsed := time.Since(started); elapsed < 90*time.Millisecond {
+		t.Fatalf("expected acme to be throttled, took %s", elapsed)
+	}
+
+	started = time.Now()
+	if _, err := globex.Account.GetWalletBalance(context.Background()); err != nil {
+		t.Fatalf("GetWalletBalance returned error: %v", err)
+	}
+	if elapsed := time.Since(started); elapsed > 40*time.Millisecond {
+		t.Fatalf("expected globex to have its own budget, took %s", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := acme.Account.GetWalletBalance(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected cancellation while waiting for the limiter, got %v", err)
+	}
+}
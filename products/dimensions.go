@@ -0,0 +1,25 @@
+package products
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ParseDimensions reads a product's listed dimensions, such as "10x8x2",
+// "10 X 8 X 2 cm" or "10*8*2", as length, breadth and height in cm.
+func ParseDimensions(value string) (length, breadth, height float64, ok bool) {
+	value = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(value)), "cm")
+	parts := strings.FieldsFunc(value, func(r rune) bool { return r == 'x' || r == '*' || r == '×' })
+	if len(parts) != 3 {
+		return 0, 0, 0, false
+	}
+	var numbers [3]float64
+	for i, part := range parts {
+		number, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return 0, 0, 0, false
+		}
+		numbers[i] = number
+	}
+	return numbers[0], numbers[1], numbers[2], true
+}
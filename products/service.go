@@ -2,11 +2,13 @@ package products
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
 
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pagination"
 )
 
 type Service struct {
@@ -17,90 +19,119 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, params *ListParams, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/products",
+		Operation: "products.List",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/products",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Get(ctx context.Context, request *GetRequest) (*GetResponse, error) {
+// ListIterator walks every product starting at params.Page.
+func (s *Service) ListIterator(params *ListParams, opts ...internalclient.CallOption) *pagination.Iterator[Summary] {
+	base := ListParams{}
+	if params != nil {
+		base = *params
+	}
+	return pagination.List(base.Page, base.PerPage, func(ctx context.Context, page int) ([]Summary, pagination.Meta, error) {
+		query := base
+		query.Page = page
+		response, err := s.List(ctx, &query, opts...)
+		if err != nil {
+			return nil, pagination.Meta{}, err
+		}
+		meta := response.Meta.Pagination
+		return response.Data, pagination.Meta{Total: meta.Total, PerPage: meta.PerPage, TotalPages: meta.TotalPages}, nil
+	})
+}
+
+func (s *Service) Get(ctx context.Context, request *GetRequest, opts ...internalclient.CallOption) (*GetResponse, error) {
 	var response GetResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/products/show/{product_id}",
+		Operation: "products.Get",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/products/show/{product_id}",
 		PathParams: map[string]string{
 			"product_id": request.ProductID,
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Create(ctx context.Context, request *CreateRequest) (*CreateResponse, error) {
+func (s *Service) Create(ctx context.Context, request *CreateRequest, opts ...internalclient.CallOption) (*CreateResponse, error) {
 	var response CreateResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
+		Operation:    "products.Create",
 		Method:       http.MethodPost,
 		Path:         "/v1/external/products",
 		JSONBody:     request,
 		ExpectedCode: []int{http.StatusCreated},
-	}, nil); err != nil {
+	}, nil, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) ConvertToQC(ctx context.Context, request *ConvertToQCRequest) (*ConvertToQCResponse, error) {
+func (s *Service) ConvertToQC(ctx context.Context, request *ConvertToQCRequest, opts ...internalclient.CallOption) (*ConvertToQCResponse, error) {
 	var response ConvertToQCResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/products/qc-product-update/{product_id}",
+		Operation: "products.ConvertToQC",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/products/qc-product-update/{product_id}",
 		PathParams: map[string]string{
 			"product_id": request.ProductID,
 		},
 		JSONBody: request.Payload,
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) Import(ctx context.Context, filePath string) (*ImportResponse, error) {
+func (s *Service) Import(ctx context.Context, filePath string, opts ...internalclient.CallOption) (*ImportResponse, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return nil, err
 	}
 	defer func() { _ = file.Close() }()
 
+	return s.ImportFrom(ctx, filepath.Base(filePath), file, opts...)
+}
+
+// ImportFrom uploads an import file read from r under the given file name.
+func (s *Service) ImportFrom(ctx context.Context, name string, r io.Reader, opts ...internalclient.CallOption) (*ImportResponse, error) {
 	var response ImportResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodPost,
-		Path:   "/v1/external/products/import",
+		Operation: "products.Import",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/products/import",
 		Multipart: &internalclient.MultipartBody{
 			Files: []internalclient.MultipartFile{{
 				FieldName: "file",
-				FileName:  filepath.Base(filePath),
-				Reader:    file,
+				FileName:  name,
+				Reader:    r,
 			}},
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) DownloadSample(ctx context.Context) (*internalclient.Download, error) {
+func (s *Service) DownloadSample(ctx context.Context, opts ...internalclient.CallOption) (*internalclient.Download, error) {
 	return s.client.DoDownload(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/products/sample",
-	})
+		Operation: "products.DownloadSample",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/products/sample",
+	}, opts...)
 }
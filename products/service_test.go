@@ -178,9 +178,33 @@ func TestProductImportAndSample(t *testing.T) {
 	if err != nil || importResp.ImportID != 20290943 {
 		t.Fatalf("unexpected import response: %+v err=%v", importResp, err)
 	}
+	importResp, err = s.ImportFrom(context.Background(), "products.csv", strings.NewReader("sku,name\nA2,Item\n"))
+	if err != nil || importResp.ImportID != 20290943 {
+		t.Fatalf("unexpected ImportFrom response: %+v err=%v", importResp, err)
+	}
 
 	download, err := s.DownloadSample(context.Background())
 	if err != nil || !strings.Contains(string(download.Body), "*Master Sku Code") || download.FileName != "products-sample.csv" {
 		t.Fatalf("unexpected sample response: %+v err=%v", download, err)
 	}
 }
+
+func TestParseDimensions(t *testing.T) {
+	tests := []struct {
+		value string
+		want  [3]float64
+		ok    bool
+	}{
+		{value: "20x15x2", want: [3]float64{20, 15, 2}, ok: true},
+		{value: "20 X 15 X 2 cm", want: [3]float64{20, 15, 2}, ok: true},
+		{value: "10.5*8*2", want: [3]float64{10.5, 8, 2}, ok: true},
+		{value: "20x15", ok: false},
+		{value: "20-15-2", ok: false},
+	}
+	for _, tt := range tests {
+		length, breadth, height, ok := ParseDimensions(tt.value)
+		if ok != tt.ok || [3]float64{length, breadth, height} != tt.want {
+			t.Fatalf("ParseDimensions(%q) = %v %v %v %v", tt.value, length, breadth, height, ok)
+		}
+	}
+}
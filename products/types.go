@@ -106,7 +106,7 @@ type CreateRequest struct {
 	Name         string         `json:"name"`
 	CategoryCode string         `json:"category_code"`
 	Type         string         `json:"type"`
-	Qty          FlexibleString `json:"qty"`
+	Qty          FlexibleString `json:"qty,omitempty"`
 	SKU          string         `json:"sku"`
 	HSN          string         `json:"hsn,omitempty"`
 	TaxCode      string         `json:"tax_code,omitempty"`
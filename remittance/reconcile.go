@@ -0,0 +1,179 @@
+package remittance
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/internal/dates"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+)
+
+const defaultRemittanceCycle = 8 * 24 * time.Hour
+
+// DeliveredShipment is a delivered COD shipment awaiting remittance.
+type DeliveredShipment struct {
+	AWB         string
+	OrderID     string
+	CODAmount   float64
+	DeliveredAt time.Time
+}
+
+// DeliveredFromTracking builds a DeliveredShipment from tracking data. It
+// reports false when the shipment has no delivery date yet. Tracking does
+// not carry the COD amount, so it comes from your order records.
+func DeliveredFromTracking(tracked shipment.TrackedShipment, codAmount float64) (DeliveredShipment, bool) {
+	if tracked.DeliveredDate == nil {
+		return DeliveredShipment{}, false
+	}
+	deliveredAt, ok := dates.Parse(*tracked.DeliveredDate)
+	if !ok {
+		return DeliveredShipment{}, false
+	}
+	delivered := DeliveredShipment{AWB: tracked.AWBCode, CODAmount: codAmount, DeliveredAt: deliveredAt}
+	if tracked.OrderID != nil {
+		delivered.OrderID = strconv.FormatInt(*tracked.OrderID, 10)
+	}
+	return delivered, true
+}
+
+type ReconcileOptions struct {
+	// Cycle is how long after delivery COD is due. Defaults to 8 days, the
+	// standard D+8 remittance cycle; use the early COD plan's days if active.
+	Cycle time.Duration
+	// Tolerance is the amount difference, in rupees, treated as a match.
+	// Defaults to 1.
+	Tolerance float64
+	// Now defaults to time.Now.
+	Now func() time.Time
+}
+
+type RemittedShipment struct {
+	DeliveredShipment
+	Remitted       float64
+	RemittanceID   string
+	RemittanceDate string
+}
+
+// Shortfall is the COD amount still owed.
+func (s RemittedShipment) Shortfall() float64 {
+	return s.CODAmount - s.Remitted
+}
+
+// Excess is the amount remitted above the COD amount.
+func (s RemittedShipment) Excess() float64 {
+	return s.Remitted - s.CODAmount
+}
+
+type OverdueShipment struct {
+	DeliveredShipment
+	DueAt       time.Time
+	DaysOverdue int
+}
+
+type Reconciliation struct {
+	// Remitted shipments were paid in full.
+	Remitted []RemittedShipment
+	// ShortPaid shipments were remitted for less than their COD amount.
+	ShortPaid []RemittedShipment
+	// Overpaid shipments were remitted for more than their COD amount, for
+	// example when the same AWB appears in two remittances.
+	Overpaid []RemittedShipment
+	// Pending shipments are not remitted yet but are still within the cycle.
+	Pending []DeliveredShipment
+	// Overdue shipments are past the cycle without a remittance.
+	Overdue []OverdueShipment
+	// Unmatched remittances are for AWBs missing from the delivered list.
+	Unmatched []RemittedAWB
+}
+
+// OverdueAmount sums the COD of overdue shipments and the shortfall of
+// short-paid ones.
+func (r *Reconciliation) OverdueAmount() float64 {
+	var total float64
+	for _, shipment := range r.Overdue {
+		total += shipment.CODAmount
+	}
+	for _, shipment := range r.ShortPaid {
+		total += shipment.Shortfall()
+	}
+	return total
+}
+
+// Reconcile matches delivered COD shipments with remitted AWBs, for example
+// from the remittance report, and lists the ones that are overdue.
+func Reconcile(delivered []DeliveredShipment, remitted []RemittedAWB, opts ReconcileOptions) *Reconciliation {
+	if opts.Cycle <= 0 {
+		opts.Cycle = defaultRemittanceCycle
+	}
+	if opts.Tolerance <= 0 {
+		opts.Tolerance = 1
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	now := opts.Now()
+
+	type payment struct {
+		amount float64
+		awb    RemittedAWB
+	}
+	paid := map[string]*payment{}
+	var order []string
+	for _, awb := range remitted {
+		key := normalizeAWB(awb.AWB)
+		if p, ok := paid[key]; ok {
+			p.amount += awb.CODAmount
+			continue
+		}
+		paid[key] = &payment{amount: awb.CODAmount, awb: awb}
+		order = append(order, key)
+	}
+
+	result := &Reconciliation{}
+	matched := map[string]bool{}
+	for _, shipment := range delivered {
+		key := normalizeAWB(shipment.AWB)
+		if p, ok := paid[key]; ok {
+			matched[key] = true
+			entry := RemittedShipment{
+				DeliveredShipment: shipment,
+				Remitted:          p.amount,
+				RemittanceID:      p.awb.RemittanceID,
+				RemittanceDate:    p.awb.RemittanceDate,
+			}
+			switch {
+			case entry.Shortfall() > opts.Tolerance:
+				result.ShortPaid = append(result.ShortPaid, entry)
+			case entry.Excess() > opts.Tolerance:
+				result.Overpaid = append(result.Overpaid, entry)
+			default:
+				result.Remitted = append(result.Remitted, entry)
+			}
+			continue
+		}
+
+		due := shipment.DeliveredAt.Add(opts.Cycle)
+		if now.Before(due) {
+			result.Pending = append(result.Pending, shipment)
+			continue
+		}
+		result.Overdue = append(result.Overdue, OverdueShipment{
+			DeliveredShipment: shipment,
+			DueAt:             due,
+			DaysOverdue:       int(math.Floor(now.Sub(due).Hours() / 24)),
+		})
+	}
+
+	for _, key := range order {
+		if !matched[key] {
+			result.Unmatched = append(result.Unmatched, paid[key].awb)
+		}
+	}
+	return result
+}
+
+func normalizeAWB(awb string) string {
+	return strings.ToUpper(strings.TrimSpace(awb))
+}
@@ -0,0 +1,61 @@
+package remittance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/shipment"
+)
+
+func TestReconcile(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 9, d, 12, 0, 0, 0, time.UTC) }
+	delivered := []DeliveredShipment{
+		{AWB: "AWB1", CODAmount: 1000, DeliveredAt: day(1)},
+		{AWB: "awb2", CODAmount: 600, DeliveredAt: day(1)},
+		{AWB: "AWB4", CODAmount: 300, DeliveredAt: day(2)},
+		{AWB: "AWB5", CODAmount: 200, DeliveredAt: day(18)},
+		{AWB: "AWB6", CODAmount: 400, DeliveredAt: day(3)},
+	}
+	remitted := []RemittedAWB{
+		{AWB: "AWB1", CODAmount: 1000, RemittanceID: "101"},
+		{AWB: "AWB2", CODAmount: 500, RemittanceID: "101"},
+		{AWB: "AWB6", CODAmount: 400, RemittanceID: "101"},
+		{AWB: "AWB6", CODAmount: 400, RemittanceID: "102"},
+		{AWB: "AWB9", CODAmount: 50, RemittanceID: "102"},
+	}
+
+	result := Reconcile(delivered, remitted, ReconcileOptions{Now: func() time.Time { return day(20) }})
+	if len(result.Remitted) != 1 || result.Remitted[0].AWB != "AWB1" || result.Remitted[0].RemittanceID != "101" {
+		t.Fatalf("unexpected remitted %+v", result.Remitted)
+	}
+	if len(result.ShortPaid) != 1 || result.ShortPaid[0].Shortfall() != 100 {
+		t.Fatalf("unexpected short-paid %+v", result.ShortPaid)
+	}
+	if len(result.Overpaid) != 1 || result.Overpaid[0].AWB != "AWB6" || result.Overpaid[0].Excess() != 400 {
+		t.Fatalf("unexpected overpaid %+v", result.Overpaid)
+	}
+	if len(result.Overdue) != 1 || result.Overdue[0].AWB != "AWB4" || result.Overdue[0].DaysOverdue != 10 {
+		t.Fatalf("unexpected overdue %+v", result.Overdue)
+	}
+	if len(result.Pending) != 1 || result.Pending[0].AWB != "AWB5" {
+		t.Fatalf("unexpected pending %+v", result.Pending)
+	}
+	if len(result.Unmatched) != 1 || result.Unmatched[0].AWB != "AWB9" {
+		t.Fatalf("unexpected unmatched %+v", result.Unmatched)
+	}
+	if result.OverdueAmount() != 400 {
+		t.Fatalf("expected 400 overdue, got %v", result.OverdueAmount())
+	}
+}
+
+func TestDeliveredFromTracking(t *testing.T) {
+	deliveredDate := "2026-09-01 15:09:00"
+	orderID := int64(501)
+	delivered, ok := DeliveredFromTracking(shipment.TrackedShipment{AWBCode: "AWB1", DeliveredDate: &deliveredDate, OrderID: &orderID}, 1000)
+	if !ok || delivered.OrderID != "501" || delivered.DeliveredAt.Format(time.RFC3339) != "2026-09-01T15:09:00+05:30" {
+		t.Fatalf("unexpected delivered shipment %+v ok=%v", delivered, ok)
+	}
+	if _, ok := DeliveredFromTracking(shipment.TrackedShipment{AWBCode: "AWB2"}, 500); ok {
+		t.Fatal("expected undelivered shipment to be skipped")
+	}
+}
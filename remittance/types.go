@@ -0,0 +1,14 @@
+// Package remittance reconciles COD remittances against delivered
+// shipments. It makes no API calls: Shiprocket does not document its
+// remittance endpoints, so remittances are read from the panel report.
+package remittance
+
+// RemittedAWB is one AWB paid in a COD remittance, as listed in the
+// remittance report from the Shiprocket panel.
+type RemittedAWB struct {
+	AWB            string
+	OrderID        string
+	CODAmount      float64
+	RemittanceID   string
+	RemittanceDate string
+}
@@ -0,0 +1,200 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
+	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+)
+
+var (
+	ErrNoServiceableCourier = errors.New("no courier can service this return")
+	ErrAWBNotAssigned       = errors.New("return AWB was not assigned")
+)
+
+// CourierStrategy picks one courier from the serviceable candidates. It
+// reports false when none of them is acceptable.
+type CourierStrategy func([]courier.ServiceableCourier) (courier.ServiceableCourier, bool)
+
+var (
+	CheapestCourier CourierStrategy = func(candidates []courier.ServiceableCourier) (courier.ServiceableCourier, bool) {
+		return pickCourier(candidates, func(a, b courier.ServiceableCourier) bool {
+			return courierRate(a) < courierRate(b)
+		})
+	}
+	FastestCourier CourierStrategy = func(candidates []courier.ServiceableCourier) (courier.ServiceableCourier, bool) {
+		return pickCourier(candidates, func(a, b courier.ServiceableCourier) bool {
+			return deliveryDays(a) < deliveryDays(b)
+		})
+	}
+	BestRatedCourier CourierStrategy = func(candidates []courier.ServiceableCourier) (courier.ServiceableCourier, bool) {
+		return pickCourier(candidates, func(a, b courier.ServiceableCourier) bool {
+			return a.Rating > b.Rating
+		})
+	}
+)
+
+type BookReturnRequest struct {
+	ShipmentID       int64
+	PickupPostcode   string
+	DeliveryPostcode string
+	Weight           string
+	Items            []ReturnOrderItem
+	Strategy         CourierStrategy
+	SkipPickup       bool
+}
+
+type BookReturnResult struct {
+	Courier    courier.ServiceableCourier
+	Candidates []courier.ServiceableCourier
+	AWB        *courier.AssignAWBResponse
+	Pickup     *courier.GeneratePickupResponse
+}
+
+func (r *BookReturnResult) AWBCode() string {
+	if r == nil || r.AWB == nil || r.AWB.Response == nil {
+		return ""
+	}
+	return r.AWB.Response.Data.AWBCode
+}
+
+// NewBookReturnRequest prepares a booking for a return order that was just
+// created. The customer pickup and warehouse postcodes are taken from the
+// original request and the shipment from the create response.
+func NewBookReturnRequest(order *CreateReturnOrderRequest, created *ReturnOrderResponse) *BookReturnRequest {
+	request := &BookReturnRequest{Strategy: CheapestCourier}
+	if order != nil {
+		request.PickupPostcode = order.PickupPincode.String()
+		request.DeliveryPostcode = order.ShippingPincode.String()
+		request.Weight = strconv.FormatFloat(order.Weight.Float64(), 'f', -1, 64)
+		request.Items = order.OrderItems
+	}
+	if created != nil {
+		request.ShipmentID = created.ShipmentID
+	}
+	return request
+}
+
+// BookReturn checks reverse-pickup serviceability, picks a courier, assigns
+// the AWB and schedules the pickup. Couriers without QC support are dropped
+// when any item requests a quality check. When pickup scheduling fails the
+// partially filled result is returned together with the error. The call
+// timeout, headers and retry policy apply to each of the three requests; an
+// idempotency key or response meta target is ignored.
+func (s *Service) BookReturn(ctx context.Context, request *BookReturnRequest, opts ...internalclient.CallOption) (*BookReturnResult, error) {
+	if request == nil {
+		request = &BookReturnRequest{}
+	}
+	opts = internalclient.SharedCallOptions(opts)
+
+	isReturn := true
+	params := &courier.ServiceabilityParams{
+		PickupPostcode:   request.PickupPostcode,
+		DeliveryPostcode: request.DeliveryPostcode,
+		Weight:           request.Weight,
+		IsReturn:         &isReturn,
+	}
+	qc := requiresQC(request.Items)
+	if qc {
+		params.QCCheck = &qc
+	}
+
+	serviceability, err := s.couriers.CheckServiceability(ctx, params, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := make([]courier.ServiceableCourier, 0, len(serviceability.Data.AvailableCourierCompanies))
+	for _, candidate := range serviceability.Data.AvailableCourierCompanies {
+		if candidate.Blocked.Int64() == 1 {
+			continue
+		}
+		if qc && candidate.QCCourier.Int64() != 1 {
+			continue
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	strategy := request.Strategy
+	if strategy == nil {
+		strategy = CheapestCourier
+	}
+	selected, ok := strategy(candidates)
+	if !ok {
+		return nil, ErrNoServiceableCourier
+	}
+
+	result := &BookReturnResult{
+		Courier:    selected,
+		Candidates: candidates,
+	}
+
+	courierID := selected.CourierCompanyID
+	result.AWB, err = s.couriers.AssignAWB(ctx, &courier.AssignAWBRequest{
+		ShipmentID: request.ShipmentID,
+		CourierID:  &courierID,
+		IsReturn:   &isReturn,
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if result.AWBCode() == "" {
+		return nil, fmt.Errorf("%w: %s", ErrAWBNotAssigned, result.AWB.Message)
+	}
+
+	if request.SkipPickup {
+		return result, nil
+	}
+
+	result.Pickup, err = s.couriers.GeneratePickup(ctx, &courier.GeneratePickupRequest{
+		ShipmentID: []int64{request.ShipmentID},
+	}, opts...)
+	if err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+func requiresQC(items []ReturnOrderItem) bool {
+	for _, item := range items {
+		if item.QCEnable != nil && *item.QCEnable {
+			return true
+		}
+	}
+	return false
+}
+
+func pickCourier(candidates []courier.ServiceableCourier, better func(a, b courier.ServiceableCourier) bool) (courier.ServiceableCourier, bool) {
+	if len(candidates) == 0 {
+		return courier.ServiceableCourier{}, false
+	}
+	best := candidates[0]
+	for _, candidate := range candidates[1:] {
+		if better(candidate, best) {
+			best = candidate
+		}
+	}
+	return best, true
+}
+
+func courierRate(candidate courier.ServiceableCourier) float64 {
+	if candidate.Rate > 0 {
+		return candidate.Rate.Float64()
+	}
+	return candidate.FreightCharge.Float64()
+}
+
+func deliveryDays(candidate courier.ServiceableCourier) float64 {
+	if days, err := strconv.ParseFloat(strings.TrimSpace(candidate.EstimatedDeliveryDays.String()), 64); err == nil && days > 0 {
+		return days
+	}
+	if candidate.EDDHours > 0 {
+		return float64(candidate.EDDHours.Int64()) / 24
+	}
+	return float64(1 << 30)
+}
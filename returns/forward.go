@@ -0,0 +1,333 @@
+package returns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Niyantra-Labs/shiprocket-gosdk/orders"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pickupaddress"
+)
+
+const (
+	returnOrderPrefix   = "R_"
+	exchangeOrderPrefix = "EX_"
+	orderDateLayout     = "2006-01-02"
+)
+
+var (
+	ErrWarehouseRequired = errors.New("return warehouse is required")
+	ErrItemNotInOrder    = errors.New("item is not part of the forward order")
+	ErrTooManyUnits      = errors.New("return units exceed ordered quantity")
+	ErrNoReturnableItems = errors.New("forward order has no returnable items")
+)
+
+var now = time.Now
+
+// ReturnItem selects a line of the forward order to send back. Units of zero
+// returns the full ordered quantity for that SKU.
+type ReturnItem struct {
+	SKU          string
+	Units        int64
+	ReturnReason string
+	QCEnable     *bool
+	QCColor      string
+	QCSize       string
+}
+
+// ExchangeItem selects a line of the forward order to exchange and describes
+// the replacement that ships back to the customer.
+type ExchangeItem struct {
+	SKU              string
+	Units            int64
+	ExchangeItemID   string
+	ExchangeItemName string
+	ExchangeItemSKU  string
+	QCEnable         *bool
+	QCColor          string
+	QCSize           string
+}
+
+type returnLine struct {
+	product orders.OrderDetailProduct
+	units   int64
+}
+
+// FromForwardOrder builds a return order for an existing forward order. The
+// customer's delivery address becomes the pickup address and the warehouse
+// becomes the shipping address. Passing no items returns the whole order.
+func (s *Service) FromForwardOrder(ctx context.Context, orderID string, items []ReturnItem, warehouse *pickupaddress.PickupAddress) (*CreateReturnOrderRequest, error) {
+	if warehouse == nil {
+		return nil, ErrWarehouseRequired
+	}
+
+	detail, err := s.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	order := detail.Data
+
+	selections := make([]lineSelection, 0, len(items))
+	for _, item := range items {
+		selections = append(selections, lineSelection{sku: item.SKU, units: item.Units})
+	}
+	lines, err := selectLines(order, selections)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := splitName(order.CustomerName)
+	warehouseFirstName, warehouseLastName := splitName(warehouse.Name)
+
+	request := &CreateReturnOrderRequest{
+		OrderID:              returnOrderPrefix + order.ChannelOrderID,
+		OrderDate:            now().Format(orderDateLayout),
+		ChannelID:            FlexibleString(strconv.FormatInt(order.ChannelID, 10)),
+		PickupCustomerName:   firstName,
+		PickupLastName:       lastName,
+		PickupAddress:        order.CustomerAddress,
+		PickupAddress2:       stringValue(order.CustomerAddress2),
+		PickupCity:           order.CustomerCity,
+		PickupState:          order.CustomerState,
+		PickupCountry:        order.CustomerCountry,
+		PickupPincode:        FlexibleString(order.CustomerPincode),
+		PickupEmail:          order.CustomerEmail,
+		PickupPhone:          order.CustomerPhone,
+		ShippingCustomerName: warehouseFirstName,
+		ShippingLastName:     warehouseLastName,
+		ShippingAddress:      warehouse.Address,
+		ShippingAddress2:     warehouse.Address2,
+		ShippingCity:         warehouse.City,
+		ShippingCountry:      warehouse.Country,
+		ShippingPincode:      FlexibleString(warehouse.PinCode),
+		ShippingState:        warehouse.State,
+		ShippingEmail:        warehouse.Email,
+		ShippingPhone:        FlexibleString(warehouse.Phone),
+		PaymentMethod:        string(orders.PaymentMethodPrepaid),
+		SubTotal:             FlexibleFloat(subTotal(lines)),
+		Length:               order.Shipments.Length,
+		Breadth:              order.Shipments.Breadth,
+		Height:               order.Shipments.Height,
+		Weight:               FlexibleFloat(returnWeight(order, lines)),
+	}
+
+	for i, line := range lines {
+		var item ReturnItem
+		if len(items) > 0 {
+			item = items[i]
+		}
+		request.OrderItems = append(request.OrderItems, ReturnOrderItem{
+			Name:         line.product.Name,
+			SKU:          line.product.SKU,
+			Units:        FlexibleInt(line.units),
+			SellingPrice: formatAmount(unitPrice(line.product)),
+			HSN:          line.product.HSN,
+			Brand:        line.product.Brand,
+			ReturnReason: item.ReturnReason,
+			QCEnable:     item.QCEnable,
+			QCColor:      item.QCColor,
+			QCSize:       item.QCSize,
+		})
+	}
+
+	return request, nil
+}
+
+// ExchangeFromForwardOrder builds an exchange order for an existing forward
+// order. The customer is both the reverse pickup and the forward delivery
+// address, while the warehouse is used as the seller pickup and return location.
+func (s *Service) ExchangeFromForwardOrder(ctx context.Context, orderID string, items []ExchangeItem, warehouse *pickupaddress.PickupAddress) (*CreateExchangeOrderRequest, error) {
+	if warehouse == nil {
+		return nil, ErrWarehouseRequired
+	}
+
+	detail, err := s.orders.GetOrderByID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	order := detail.Data
+
+	selections := make([]lineSelection, 0, len(items))
+	for _, item := range items {
+		selections = append(selections, lineSelection{sku: item.SKU, units: item.Units})
+	}
+	lines, err := selectLines(order, selections)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := splitName(order.CustomerName)
+	warehouseID := FlexibleString(strconv.FormatInt(warehouse.ID, 10))
+	length := formatAmount(order.Shipments.Length.Float64())
+	breadth := formatAmount(order.Shipments.Breadth.Float64())
+	height := formatAmount(order.Shipments.Height.Float64())
+	weight := formatAmount(returnWeight(order, lines))
+
+	request := &CreateExchangeOrderRequest{
+		BuyerPickupFirstName:     firstName,
+		BuyerPickupLastName:      lastName,
+		BuyerPickupEmail:         order.CustomerEmail,
+		BuyerPickupAddress:       order.CustomerAddress,
+		BuyerPickupAddress2:      stringValue(order.CustomerAddress2),
+		BuyerPickupCity:          order.CustomerCity,
+		BuyerPickupState:         order.CustomerState,
+		BuyerPickupCountry:       order.CustomerCountry,
+		BuyerPickupPhone:         order.CustomerPhone,
+		BuyerPickupPincode:       order.CustomerPincode,
+		BuyerShippingFirstName:   firstName,
+		BuyerShippingLastName:    lastName,
+		BuyerShippingEmail:       order.CustomerEmail,
+		BuyerShippingAddress:     order.CustomerAddress,
+		BuyerShippingAddress2:    stringValue(order.CustomerAddress2),
+		BuyerShippingCity:        order.CustomerCity,
+		BuyerShippingState:       order.CustomerState,
+		BuyerShippingCountry:     order.CustomerCountry,
+		BuyerShippingPhone:       order.CustomerPhone,
+		BuyerShippingPincode:     order.CustomerPincode,
+		SellerPickupLocationID:   warehouseID,
+		SellerShippingLocationID: warehouseID,
+		ExchangeOrderID:          exchangeOrderPrefix + order.ChannelOrderID,
+		ReturnOrderID:            returnOrderPrefix + order.ChannelOrderID,
+		PaymentMethod:            string(orders.PaymentMethodPrepaid),
+		OrderDate:                now().Format(orderDateLayout),
+		ChannelID:                FlexibleString(strconv.FormatInt(order.ChannelID, 10)),
+		ExistingOrderID:          order.ChannelOrderID,
+		SubTotal:                 formatAmount(subTotal(lines)),
+		ExchangeLength:           length,
+		ExchangeBreadth:          breadth,
+		ExchangeHeight:           height,
+		ExchangeWeight:           weight,
+		ReturnLength:             length,
+		ReturnBreadth:            breadth,
+		ReturnHeight:             height,
+		ReturnWeight:             weight,
+	}
+
+	for i, line := range lines {
+		var item ExchangeItem
+		if len(items) > 0 {
+			item = items[i]
+		}
+		exchangeSKU := item.ExchangeItemSKU
+		if exchangeSKU == "" {
+			exchangeSKU = line.product.SKU
+		}
+		exchangeName := item.ExchangeItemName
+		if exchangeName == "" {
+			exchangeName = line.product.Name
+		}
+		request.OrderItems = append(request.OrderItems, ExchangeOrderItem{
+			Name:             line.product.Name,
+			SellingPrice:     formatAmount(unitPrice(line.product)),
+			Units:            FlexibleString(strconv.FormatInt(line.units, 10)),
+			HSN:              line.product.HSN,
+			SKU:              line.product.SKU,
+			Brand:            line.product.Brand,
+			Color:            line.product.Color,
+			ExchangeItemID:   FlexibleString(item.ExchangeItemID),
+			ExchangeItemName: exchangeName,
+			ExchangeItemSKU:  exchangeSKU,
+			QCEnable:         item.QCEnable,
+			QCColor:          item.QCColor,
+			QCSize:           item.QCSize,
+		})
+	}
+
+	return request, nil
+}
+
+type lineSelection struct {
+	sku   string
+	units int64
+}
+
+func selectLines(order orders.OrderDetail, selections []lineSelection) ([]returnLine, error) {
+	if len(selections) == 0 {
+		lines := make([]returnLine, 0, len(order.Products))
+		for _, product := range order.Products {
+			if product.Quantity.Int64() <= 0 {
+				continue
+			}
+			lines = append(lines, returnLine{product: product, units: product.Quantity.Int64()})
+		}
+		if len(lines) == 0 {
+			return nil, ErrNoReturnableItems
+		}
+		return lines, nil
+	}
+
+	lines := make([]returnLine, 0, len(selections))
+	for _, selection := range selections {
+		product, ok := findProduct(order.Products, selection.sku)
+		if !ok {
+			return nil, fmt.Errorf("%w: sku %q in order %d", ErrItemNotInOrder, selection.sku, order.ID)
+		}
+		units := selection.units
+		if units <= 0 {
+			units = product.Quantity.Int64()
+		}
+		if units > product.Quantity.Int64() {
+			return nil, fmt.Errorf("%w: sku %q has %d units, requested %d", ErrTooManyUnits, selection.sku, product.Quantity.Int64(), units)
+		}
+		lines = append(lines, returnLine{product: product, units: units})
+	}
+
+	return lines, nil
+}
+
+func findProduct(products []orders.OrderDetailProduct, sku string) (orders.OrderDetailProduct, bool) {
+	for _, product := range products {
+		if product.SKU == sku || product.ChannelSKU == sku {
+			return product, true
+		}
+	}
+	return orders.OrderDetailProduct{}, false
+}
+
+func unitPrice(product orders.OrderDetailProduct) float64 {
+	if product.SellingPrice > 0 {
+		return product.SellingPrice.Float64()
+	}
+	return product.Price.Float64()
+}
+
+func subTotal(lines []returnLine) float64 {
+	var total float64
+	for _, line := range lines {
+		total += unitPrice(line.product) * float64(line.units)
+	}
+	return total
+}
+
+// returnWeight uses per-product weights when every returned line carries one
+// so partial returns are not billed at the full forward weight.
+func returnWeight(order orders.OrderDetail, lines []returnLine) float64 {
+	var total float64
+	for _, line := range lines {
+		if line.product.Weight <= 0 {
+			return order.Shipments.Weight.Float64()
+		}
+		total += line.product.Weight.Float64() * float64(line.units)
+	}
+	return total
+}
+
+func splitName(name string) (string, string) {
+	name = strings.TrimSpace(name)
+	first, last, _ := strings.Cut(name, " ")
+	return first, strings.TrimSpace(last)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func formatAmount(value float64) FlexibleString {
+	return FlexibleString(strconv.FormatFloat(value, 'f', -1, 64))
+}
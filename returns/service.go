@@ -6,72 +6,79 @@ import (
 
 	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/orders"
 )
 
 type Service struct {
 	client   *internalclient.Client
 	couriers *courier.Service
+	orders   *orders.Service
 }
 
 func NewService(client *internalclient.Client) *Service {
 	return &Service{
 		client:   client,
 		couriers: courier.NewService(client),
+		orders:   orders.NewService(client),
 	}
 }
 
-func (s *Service) CreateReturnOrder(ctx context.Context, request *CreateReturnOrderRequest) (*ReturnOrderResponse, error) {
+func (s *Service) CreateReturnOrder(ctx context.Context, request *CreateReturnOrderRequest, opts ...internalclient.CallOption) (*ReturnOrderResponse, error) {
 	var response ReturnOrderResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/create/return",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "returns.CreateReturnOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/create/return",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CreateExchangeOrder(ctx context.Context, request *CreateExchangeOrderRequest) (*CreateExchangeOrderResponse, error) {
+func (s *Service) CreateExchangeOrder(ctx context.Context, request *CreateExchangeOrderRequest, opts ...internalclient.CallOption) (*CreateExchangeOrderResponse, error) {
 	var response CreateExchangeOrderResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/create/exchange",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "returns.CreateExchangeOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/create/exchange",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) UpdateReturnOrder(ctx context.Context, request *UpdateReturnOrderRequest) (*UpdateReturnOrderResponse, error) {
+func (s *Service) UpdateReturnOrder(ctx context.Context, request *UpdateReturnOrderRequest, opts ...internalclient.CallOption) (*UpdateReturnOrderResponse, error) {
 	var response UpdateReturnOrderResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/edit",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "returns.UpdateReturnOrder",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/edit",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) ListReturnOrders(ctx context.Context, params *ListReturnOrdersParams) (*ListReturnOrdersResponse, error) {
+func (s *Service) ListReturnOrders(ctx context.Context, params *ListReturnOrdersParams, opts ...internalclient.CallOption) (*ListReturnOrdersResponse, error) {
 	var response ListReturnOrdersResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/orders/processing/return",
+		Operation: "returns.ListReturnOrders",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/orders/processing/return",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 	return &response, nil
 }
 
-func (s *Service) CheckServiceability(ctx context.Context, params *courier.ServiceabilityParams) (*courier.ServiceabilityResponse, error) {
+func (s *Service) CheckServiceability(ctx context.Context, params *courier.ServiceabilityParams, opts ...internalclient.CallOption) (*courier.ServiceabilityResponse, error) {
 	if params == nil {
 		params = &courier.ServiceabilityParams{}
 	}
@@ -79,10 +86,10 @@ func (s *Service) CheckServiceability(ctx context.Context, params *courier.Servi
 		isReturn := true
 		params.IsReturn = &isReturn
 	}
-	return s.couriers.CheckServiceability(ctx, params)
+	return s.couriers.CheckServiceability(ctx, params, opts...)
 }
 
-func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest) (*courier.AssignAWBResponse, error) {
+func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBRequest, opts ...internalclient.CallOption) (*courier.AssignAWBResponse, error) {
 	if request == nil {
 		request = &courier.AssignAWBRequest{}
 	}
@@ -90,5 +97,5 @@ func (s *Service) AssignAWB(ctx context.Context, request *courier.AssignAWBReque
 		isReturn := true
 		request.IsReturn = &isReturn
 	}
-	return s.couriers.AssignAWB(ctx, request)
+	return s.couriers.AssignAWB(ctx, request, opts...)
 }
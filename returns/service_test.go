@@ -3,14 +3,17 @@ package returns
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Niyantra-Labs/shiprocket-gosdk/courier"
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
+	"github.com/Niyantra-Labs/shiprocket-gosdk/pickupaddress"
 )
 
 func TestReturnAndExchangeEndpointsSendDocumentedPayloads(t *testing.T) {
@@ -281,3 +284,208 @@ func assertJSONEqual(t *testing.T, expected string, actual string) {
 		t.Fatalf("unexpected json body:\nexpected: %s\nactual:   %s", expected, actual)
 	}
 }
+
+const forwardOrderDetailJSON = `{"data":{"id":259492257,"channel_id":38026,"channel_order_id":"1873081902","customer_name":"Asha Rao","customer_email":"asha@example.com","customer_phone":"9876543210","customer_address":"12 MG Road","customer_address_2":"Near Metro","customer_city":"Bengaluru","customer_state":"Karnataka","customer_pincode":"560001","customer_country":"India","payment_method":"cod","products":[{"id":1,"name":"Running Shoes","sku":"SHOE-42","channel_sku":"SHOE-42","hsn":"6404","brand":"Stride","color":"Blue","quantity":2,"selling_price":1200,"price":1200,"weight":0.8},{"id":2,"name":"Socks","sku":"SOCK-1","channel_sku":"SOCK-1","hsn":"6115","quantity":3,"selling_price":"150","weight":0.1}],"shipments":{"id":99,"length":30,"breadth":20,"height":12,"weight":2.1}}}`
+
+func newForwardOrderServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/v1/external/orders/show/259492257" {
+			t.Fatalf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(forwardOrderDetailJSON))
+	}))
+}
+
+func TestFromForwardOrderSwapsAddressesAndSupportsPartialReturns(t *testing.T) {
+	server := newForwardOrderServer(t)
+	defer server.Close()
+
+	now = func() time.Time { return time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC) }
+	defer func() { now = time.Now }()
+
+	trueValue := true
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	request, err := service.FromForwardOrder(context.Background(), "259492257", []ReturnItem{
+		{SKU: "SHOE-42", Units: 1, ReturnReason: "size issue", QCEnable: &trueValue, QCSize: "42"},
+	}, &pickupaddress.PickupAddress{
+		ID:      1072,
+		Name:    "Central Warehouse",
+		Email:   "returns@example.com",
+		Phone:   "9999999999",
+		Address: "Plot 7, Industrial Area",
+		City:    "Gurugram",
+		State:   "Haryana",
+		Country: "India",
+		PinCode: "122001",
+	})
+	if err != nil {
+		t.Fatalf("FromForwardOrder returned error: %v", err)
+	}
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	assertJSONEqual(t, `{"order_id":"R_1873081902","order_date":"2026-10-19","channel_id":"38026","pickup_customer_name":"Asha","pickup_last_name":"Rao","pickup_address":"12 MG Road","pickup_address_2":"Near Metro","pickup_city":"Bengaluru","pickup_state":"Karnataka","pickup_country":"India","pickup_pincode":"560001","pickup_email":"asha@example.com","pickup_phone":"9876543210","shipping_customer_name":"Central","shipping_last_name":"Warehouse","shipping_address":"Plot 7, Industrial Area","shipping_address_2":"","shipping_city":"Gurugram","shipping_country":"India","shipping_pincode":"122001","shipping_state":"Haryana","shipping_email":"returns@example.com","shipping_isd_code":"","shipping_phone":"9999999999","order_items":[{"name":"Running Shoes","sku":"SHOE-42","units":1,"selling_price":"1200","hsn":"6404","brand":"Stride","return_reason":"size issue","qc_enable":true,"qc_size":"42"}],"payment_method":"Prepaid","sub_total":1200,"length":30,"breadth":20,"height":12,"weight":0.8}`, string(body))
+}
+
+func TestFromForwardOrderReturnsWholeOrderByDefault(t *testing.T) {
+	server := newForwardOrderServer(t)
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	request, err := service.FromForwardOrder(context.Background(), "259492257", nil, &pickupaddress.PickupAddress{Name: "Warehouse"})
+	if err != nil {
+		t.Fatalf("FromForwardOrder returned error: %v", err)
+	}
+	if len(request.OrderItems) != 2 || request.OrderItems[1].Units != 3 {
+		t.Fatalf("unexpected items: %+v", request.OrderItems)
+	}
+	if request.SubTotal != 2850 {
+		t.Fatalf("unexpected sub total: %v", request.SubTotal)
+	}
+	if request.Weight.Float64() < 1.89 || request.Weight.Float64() > 1.91 {
+		t.Fatalf("unexpected weight: %v", request.Weight)
+	}
+}
+
+func TestFromForwardOrderRejectsUnknownItemsAndExcessUnits(t *testing.T) {
+	server := newForwardOrderServer(t)
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	warehouse := &pickupaddress.PickupAddress{Name: "Warehouse"}
+
+	if _, err := service.FromForwardOrder(context.Background(), "259492257", []ReturnItem{{SKU: "MISSING"}}, warehouse); !errors.Is(err, ErrItemNotInOrder) {
+		t.Fatalf("expected ErrItemNotInOrder, got %v", err)
+	}
+	if _, err := service.FromForwardOrder(context.Background(), "259492257", []ReturnItem{{SKU: "SOCK-1", Units: 4}}, warehouse); !errors.Is(err, ErrTooManyUnits) {
+		t.Fatalf("expected ErrTooManyUnits, got %v", err)
+	}
+	if _, err := service.FromForwardOrder(context.Background(), "259492257", nil, nil); !errors.Is(err, ErrWarehouseRequired) {
+		t.Fatalf("expected ErrWarehouseRequired, got %v", err)
+	}
+}
+
+func TestExchangeFromForwardOrderUsesWarehouseLocations(t *testing.T) {
+	server := newForwardOrderServer(t)
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	request, err := service.ExchangeFromForwardOrder(context.Background(), "259492257", []ExchangeItem{
+		{SKU: "SHOE-42", Units: 1, ExchangeItemSKU: "SHOE-43", ExchangeItemName: "Running Shoes 43"},
+	}, &pickupaddress.PickupAddress{ID: 5723898, Name: "Warehouse"})
+	if err != nil {
+		t.Fatalf("ExchangeFromForwardOrder returned error: %v", err)
+	}
+	if request.SellerPickupLocationID != "5723898" || request.SellerShippingLocationID != "5723898" {
+		t.Fatalf("unexpected seller locations: %+v", request)
+	}
+	if request.BuyerPickupPincode != "560001" || request.BuyerShippingPincode != "560001" {
+		t.Fatalf("unexpected buyer pincodes: %+v", request)
+	}
+	if request.ExchangeOrderID != "EX_1873081902" || request.ReturnOrderID != "R_1873081902" || request.ExistingOrderID != "1873081902" {
+		t.Fatalf("unexpected order ids: %+v", request)
+	}
+	if len(request.OrderItems) != 1 || request.OrderItems[0].ExchangeItemSKU != "SHOE-43" || request.OrderItems[0].Units != "1" {
+		t.Fatalf("unexpected items: %+v", request.OrderItems)
+	}
+	if request.ReturnWeight != "0.8" || request.ReturnLength != "30" {
+		t.Fatalf("unexpected dimensions: %+v", request)
+	}
+}
+
+func TestBookReturnFiltersQCCouriersAssignsAWBAndSchedulesPickup(t *testing.T) {
+	var assigned, pickedUp bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get(internalclient.IdempotencyKeyHeader) != "" || r.Header.Get("X-Trace") != "trace-1" {
+			t.Fatalf("unexpected call option headers on %s: %v", r.URL.Path, r.Header)
+		}
+		switch r.URL.Path {
+		case "/v1/external/courier/serviceability/":
+			query := r.URL.Query()
+			if query.Get("is_return") != "1" || query.Get("qc_check") != "1" {
+				t.Fatalf("unexpected serviceability query: %s", r.URL.RawQuery)
+			}
+			_, _ = w.Write([]byte(`{"data":{"available_courier_companies":[{"courier_company_id":1,"courier_name":"Cheap No QC","rate":40,"qc_courier":0},{"courier_company_id":2,"courier_name":"QC Express","rate":90,"qc_courier":1,"estimated_delivery_days":"2"},{"courier_company_id":3,"courier_name":"QC Saver","rate":70,"qc_courier":1,"estimated_delivery_days":"5"}]}}`))
+		case "/v1/external/courier/assign/awb":
+			assigned = true
+			body, _ := io.ReadAll(r.Body)
+			assertJSONEqual(t, `{"shipment_id":170411259,"courier_id":3,"is_return":true}`, string(body))
+			_, _ = w.Write([]byte(`{"awb_assign_status":1,"response":{"data":{"courier_company_id":3,"awb_code":"RET999","shipment_id":170411259}}}`))
+		case "/v1/external/courier/generate/pickup":
+			pickedUp = true
+			body, _ := io.ReadAll(r.Body)
+			assertJSONEqual(t, `{"shipment_id":[170411259]}`, string(body))
+			_, _ = w.Write([]byte(`{"pickup_status":1,"response":{"pickup_scheduled_date":"2026-10-20 10:00:00","pickup_token_number":"TKN1","status":3}}`))
+		default:
+			t.Fatalf("unexpected path: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	trueValue := true
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	request := NewBookReturnRequest(&CreateReturnOrderRequest{
+		PickupPincode:   "560001",
+		ShippingPincode: "122001",
+		Weight:          0.8,
+		OrderItems:      []ReturnOrderItem{{SKU: "SHOE-42", QCEnable: &trueValue}},
+	}, &ReturnOrderResponse{ShipmentID: 170411259})
+
+	var meta internalclient.ResponseMeta
+	result, err := service.BookReturn(context.Background(), request,
+		internalclient.WithIdempotencyKey("book-1"),
+		internalclient.WithHeader("X-Trace", "trace-1"),
+		internalclient.CaptureResponseMeta(&meta),
+	)
+	if err != nil {
+		t.Fatalf("BookReturn returned error: %v", err)
+	}
+	if meta.StatusCode != 0 {
+		t.Fatalf("expected response meta to be ignored, got %+v", meta)
+	}
+	if !assigned || !pickedUp {
+		t.Fatalf("expected AWB assignment and pickup, got assigned=%v pickup=%v", assigned, pickedUp)
+	}
+	if result.Courier.CourierCompanyID != 3 || len(result.Candidates) != 2 {
+		t.Fatalf("unexpected courier selection: %+v", result)
+	}
+	if result.AWBCode() != "RET999" || result.Pickup == nil || result.Pickup.Response.PickupTokenNumber != "TKN1" {
+		t.Fatalf("unexpected booking result: %+v", result)
+	}
+}
+
+func TestBookReturnStrategiesAndMissingCouriers(t *testing.T) {
+	candidates := []courier.ServiceableCourier{
+		{CourierCompanyID: 1, Rate: 90, Rating: 4.1, EstimatedDeliveryDays: "4"},
+		{CourierCompanyID: 2, Rate: 60, Rating: 3.2, EstimatedDeliveryDays: "6"},
+		{CourierCompanyID: 3, Rate: 75, Rating: 4.8, EstimatedDeliveryDays: "2"},
+	}
+	for name, tt := range map[string]struct {
+		strategy CourierStrategy
+		want     int64
+	}{
+		"cheapest":   {CheapestCourier, 2},
+		"fastest":    {FastestCourier, 3},
+		"best rated": {BestRatedCourier, 3},
+	} {
+		selected, ok := tt.strategy(candidates)
+		if !ok || selected.CourierCompanyID != tt.want {
+			t.Fatalf("%s: unexpected selection %+v", name, selected)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":{"available_courier_companies":[]}}`))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	if _, err := service.BookReturn(context.Background(), &BookReturnRequest{ShipmentID: 1}); !errors.Is(err, ErrNoServiceableCourier) {
+		t.Fatalf("expected ErrNoServiceableCourier, got %v", err)
+	}
+}
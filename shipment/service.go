@@ -17,233 +17,221 @@ func NewService(client *internalclient.Client) *Service {
 	return &Service{client: client}
 }
 
-func (s *Service) List(ctx context.Context, params *ListParams) (*ListResponse, error) {
+func (s *Service) List(ctx context.Context, params *ListParams, opts ...internalclient.CallOption) (*ListResponse, error) {
 	var response ListResponse
 	request := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/shipments",
+		Operation: "shipment.List",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/shipments",
 	}
 	if params != nil {
 		request.Query = params.QueryValues()
 	}
-	if err := s.client.Do(ctx, request, &response); err != nil {
+	if err := s.client.Do(ctx, request, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) Get(ctx context.Context, request *GetRequest) (*DetailResponse, error) {
+func (s *Service) Get(ctx context.Context, request *GetRequest, opts ...internalclient.CallOption) (*DetailResponse, error) {
 	var response DetailResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/shipments/{shipment_id}",
+		Operation: "shipment.Get",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/shipments/{shipment_id}",
 		PathParams: map[string]string{
 			"shipment_id": fmt.Sprintf("%d", request.ShipmentID),
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) CancelByAWB(ctx context.Context, request *CancelShipmentsRequest) (*CancelShipmentsResponse, error) {
+func (s *Service) CancelByAWB(ctx context.Context, request *CancelShipmentsRequest, opts ...internalclient.CallOption) (*CancelShipmentsResponse, error) {
 	var response CancelShipmentsResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/cancel/shipment/awbs",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.CancelByAWB",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/cancel/shipment/awbs",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GenerateManifest(ctx context.Context, request *GenerateManifestRequest) (*GenerateManifestResponse, error) {
+func (s *Service) GenerateManifest(ctx context.Context, request *GenerateManifestRequest, opts ...internalclient.CallOption) (*GenerateManifestResponse, error) {
 	var response GenerateManifestResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/manifests/generate",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.GenerateManifest",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/manifests/generate",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) PrintManifest(ctx context.Context, request *PrintManifestRequest) (*PrintManifestResponse, error) {
+func (s *Service) PrintManifest(ctx context.Context, request *PrintManifestRequest, opts ...internalclient.CallOption) (*PrintManifestResponse, error) {
 	var response PrintManifestResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/manifests/print",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.PrintManifest",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/manifests/print",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GenerateLabel(ctx context.Context, request *GenerateLabelRequest) (*GenerateLabelResponse, error) {
+func (s *Service) GenerateLabel(ctx context.Context, request *GenerateLabelRequest, opts ...internalclient.CallOption) (*GenerateLabelResponse, error) {
 	var response GenerateLabelResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/courier/generate/label",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.GenerateLabel",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/courier/generate/label",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GenerateInvoice(ctx context.Context, request *GenerateInvoiceRequest) (*GenerateInvoiceResponse, error) {
+func (s *Service) GenerateInvoice(ctx context.Context, request *GenerateInvoiceRequest, opts ...internalclient.CallOption) (*GenerateInvoiceResponse, error) {
 	var response GenerateInvoiceResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/orders/print/invoice",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.GenerateInvoice",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/orders/print/invoice",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) GenerateCombinedLabelInvoice(ctx context.Context, request *GenerateCombinedLabelInvoiceRequest) (*GenerateCombinedLabelInvoiceResponse, error) {
+func (s *Service) GenerateCombinedLabelInvoice(ctx context.Context, request *GenerateCombinedLabelInvoiceRequest, opts ...internalclient.CallOption) (*GenerateCombinedLabelInvoiceResponse, error) {
 	var response GenerateCombinedLabelInvoiceResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/courier/generate/label-invoice",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.GenerateCombinedLabelInvoice",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/courier/generate/label-invoice",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) TrackByAWB(ctx context.Context, request *TrackByAWBRequest) (*TrackingResponse, error) {
+func (s *Service) TrackByAWB(ctx context.Context, request *TrackByAWBRequest, opts ...internalclient.CallOption) (*TrackingResponse, error) {
 	var response TrackingResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/courier/track/awb/{awb_code}",
+		Operation: "shipment.TrackByAWB",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/courier/track/awb/{awb_code}",
 		PathParams: map[string]string{
 			"awb_code": request.AWBCode,
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) TrackByAWBs(ctx context.Context, request *TrackByAWBsRequest) (MultiTrackingResponse, error) {
+func (s *Service) TrackByAWBs(ctx context.Context, request *TrackByAWBsRequest, opts ...internalclient.CallOption) (MultiTrackingResponse, error) {
 	var response MultiTrackingResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method:   http.MethodPost,
-		Path:     "/v1/external/courier/track/awbs",
-		JSONBody: request,
-	}, &response); err != nil {
+		Operation: "shipment.TrackByAWBs",
+		Method:    http.MethodPost,
+		Path:      "/v1/external/courier/track/awbs",
+		JSONBody:  request,
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return response, nil
 }
 
-func (s *Service) TrackByShipmentID(ctx context.Context, request *TrackByShipmentIDRequest) (*TrackingResponse, error) {
+func (s *Service) TrackByShipmentID(ctx context.Context, request *TrackByShipmentIDRequest, opts ...internalclient.CallOption) (*TrackingResponse, error) {
 	var response TrackingResponse
 	if err := s.client.Do(ctx, &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/courier/track/shipment/{shipment_id}",
+		Operation: "shipment.TrackByShipmentID",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/courier/track/shipment/{shipment_id}",
 		PathParams: map[string]string{
 			"shipment_id": fmt.Sprintf("%d", request.ShipmentID),
 		},
-	}, &response); err != nil {
+	}, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return &response, nil
 }
 
-func (s *Service) TrackByOrder(ctx context.Context, request *TrackByOrderRequest) (OrderTrackingResponse, error) {
+func (s *Service) TrackByOrder(ctx context.Context, request *TrackByOrderRequest, opts ...internalclient.CallOption) (OrderTrackingResponse, error) {
 	var response OrderTrackingResponse
 	httpRequest := &internalclient.Request{
-		Method: http.MethodGet,
-		Path:   "/v1/external/courier/track",
-		Query:  request.QueryValues(),
+		Operation: "shipment.TrackByOrder",
+		Method:    http.MethodGet,
+		Path:      "/v1/external/courier/track",
+		Query:     request.QueryValues(),
 	}
-	if err := s.client.Do(ctx, httpRequest, &response); err != nil {
+	if err := s.client.Do(ctx, httpRequest, &response, opts...); err != nil {
 		return nil, err
 	}
 
 	return response, nil
 }
 
-func (s *Service) DownloadArtifact(ctx context.Context, artifactURL string) (*internalclient.Download, error) {
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, artifactURL, nil)
+// DownloadArtifact fetches a generated document URL into memory. Use
+// SaveArtifact for large files.
+func (s *Service) DownloadArtifact(ctx context.Context, artifactURL string, opts ...internalclient.CallOption) (*internalclient.Download, error) {
+	stream, err := s.client.StreamURL(ctx, "shipment.DownloadArtifact", artifactURL, nil, opts...)
 	if err != nil {
 		return nil, err
 	}
-	if s.client.UserAgent != "" {
-		request.Header.Set("User-Agent", s.client.UserAgent)
-	}
-
-	for _, hook := range s.client.Hooks {
-		hook.Before(request)
-	}
+	defer func() { _ = stream.Close() }()
 
-	response, err := s.client.HTTPClient.Do(request)
-	for _, hook := range s.client.Hooks {
-		hook.After(response, err)
-	}
-	if err != nil {
-		return nil, err
-	}
-	defer func() { _ = response.Body.Close() }()
-
-	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
-		return nil, internalclient.DecodeResponse(response, nil)
-	}
-
-	body, err := io.ReadAll(response.Body)
+	body, err := io.ReadAll(stream)
 	if err != nil {
 		return nil, err
 	}
 
 	return &internalclient.Download{
-		StatusCode:  response.StatusCode,
-		Headers:     response.Header.Clone(),
-		ContentType: response.Header.Get("Content-Type"),
-		FileName:    downloadFileName(response),
+		StatusCode:  stream.StatusCode,
+		Headers:     stream.Headers,
+		ContentType: stream.ContentType,
+		FileName:    stream.FileName,
 		Body:        body,
 	}, nil
 }
 
-func downloadFileName(response *http.Response) string {
-	disposition := response.Header.Get("Content-Disposition")
-	if disposition == "" {
-		return ""
-	}
+// StreamArtifact fetches a generated document URL without buffering the body.
+// The caller must close the returned stream.
+func (s *Service) StreamArtifact(ctx context.Context, artifactURL string, opts *internalclient.StreamOptions, callOpts ...internalclient.CallOption) (*internalclient.Stream, error) {
+	return s.client.StreamURL(ctx, "shipment.StreamArtifact", artifactURL, opts, callOpts...)
+}
 
-	const marker = `filename="`
-	index := len(marker)
-	start := -1
-	for i := 0; i+index <= len(disposition); i++ {
-		if disposition[i:i+index] == marker {
-			start = i + index
-			break
+// SaveArtifact streams a generated document URL to path, resuming an earlier
+// partial download when the artifact host supports range requests.
+func (s *Service) SaveArtifact(ctx context.Context, artifactURL string, path string, opts *internalclient.StreamOptions, callOpts ...internalclient.CallOption) (*internalclient.SavedFile, error) {
+	return internalclient.SaveFile(path, func(offset int64, validator string) (*internalclient.Stream, error) {
+		options := internalclient.StreamOptions{Offset: offset, IfRange: validator}
+		if opts != nil {
+			options.MaxSize = opts.MaxSize
 		}
-	}
-	if start == -1 {
-		return ""
-	}
-	end := start
-	for end < len(disposition) && disposition[end] != '"' {
-		end++
-	}
-	return disposition[start:end]
+		return s.client.StreamURL(ctx, "shipment.SaveArtifact", artifactURL, &options, callOpts...)
+	})
 }
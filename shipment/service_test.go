@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	internalclient "github.com/Niyantra-Labs/shiprocket-gosdk/internal/client"
@@ -320,6 +322,10 @@ func TestDownloadArtifactUsesSharedHTTPClient(t *testing.T) {
 		if got := r.Header.Get("User-Agent"); got != "shiprocket-tests" {
 			t.Fatalf("unexpected user agent: %q", got)
 		}
+		if got := r.Header.Get("X-Trace-Id"); got != "trace-1" {
+			t.Fatalf("expected the call option header, got %q", got)
+		}
+		w.Header().Set("X-Request-Id", "req-artifact")
 		w.Header().Set("Content-Type", "application/pdf")
 		w.Header().Set("Content-Disposition", `attachment; filename="combined-label-invoice.pdf"`)
 		_, _ = w.Write([]byte("%PDF-1.4"))
@@ -327,10 +333,14 @@ func TestDownloadArtifactUsesSharedHTTPClient(t *testing.T) {
 	defer server.Close()
 
 	service := NewService(internalclient.New(server.URL, internalclient.WithUserAgent("shiprocket-tests")))
-	download, err := service.DownloadArtifact(context.Background(), server.URL+"/artifact.pdf")
+	var meta internalclient.ResponseMeta
+	download, err := service.DownloadArtifact(context.Background(), server.URL+"/artifact.pdf", internalclient.WithHeader("X-Trace-Id", "trace-1"), internalclient.CaptureResponseMeta(&meta))
 	if err != nil {
 		t.Fatalf("DownloadArtifact returned error: %v", err)
 	}
+	if meta.RequestID != "req-artifact" {
+		t.Fatalf("unexpected response meta: %+v", meta)
+	}
 	if download.FileName != "combined-label-invoice.pdf" {
 		t.Fatalf("unexpected filename: %+v", download)
 	}
@@ -339,6 +349,31 @@ func TestDownloadArtifactUsesSharedHTTPClient(t *testing.T) {
 	}
 }
 
+func TestSaveArtifactStreamsToFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "" {
+			t.Fatalf("artifact request must not carry the API token")
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write([]byte("%PDF-1.4 manifest"))
+	}))
+	defer server.Close()
+
+	service := NewService(internalclient.New(server.URL, internalclient.WithToken("secret")))
+	target := filepath.Join(t.TempDir(), "manifest.pdf")
+	saved, err := service.SaveArtifact(context.Background(), server.URL+"/manifest.pdf", target, &internalclient.StreamOptions{MaxSize: 1024})
+	if err != nil {
+		t.Fatalf("SaveArtifact returned error: %v", err)
+	}
+	if saved.Size != 17 || saved.ContentType != "application/pdf" || len(saved.SHA256) != 64 {
+		t.Fatalf("unexpected saved file: %+v", saved)
+	}
+	written, err := os.ReadFile(target)
+	if err != nil || string(written) != "%PDF-1.4 manifest" {
+		t.Fatalf("unexpected file content: %q, %v", written, err)
+	}
+}
+
 func assertJSONEqual(t *testing.T, expected string, actual string) {
 	t.Helper()
 